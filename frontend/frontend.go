@@ -0,0 +1,166 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/danthegoodman1/EpicEpoch/gologger"
+	"github.com/danthegoodman1/EpicEpoch/raft"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+)
+
+var logger = gologger.NewLogger()
+
+// ErrLeadershipNotReady is returned when the raft group has no leader yet, clients should retry later
+var ErrLeadershipNotReady = errors.New("raft leadership not ready")
+
+// NotLeaderError is returned on a node that can't issue timestamps because another node is the leader
+type NotLeaderError struct {
+	NodeID uint64
+	Leader uint64
+	// Addr is the leader's advertised address, or empty if it isn't known
+	Addr string
+}
+
+func (e *NotLeaderError) Error() string {
+	return fmt.Sprintf("node (%d) is not the leader (%d)", e.NodeID, e.Leader)
+}
+
+// Conns are the connections of a frontend listener, which are served until shutdown
+type Conns struct {
+	// Ctx is cancelled on shutdown, requests are issued under it
+	Ctx context.Context
+
+	name     string
+	listener io.Closer
+	cancel   context.CancelFunc
+	mu       sync.Mutex
+	conns    map[io.Closer]struct{}
+	wg       sync.WaitGroup
+}
+
+// NewConns tracks the connections of listener, name is the protocol for logs and errors
+func NewConns(name string, listener io.Closer) *Conns {
+	c := &Conns{
+		name:     name,
+		listener: listener,
+		conns:    map[io.Closer]struct{}{},
+	}
+	c.Ctx, c.cancel = context.WithCancel(context.Background())
+	return c
+}
+
+// AcceptLoop serves every connection accept returns in its own goroutine, until the listener is closed.
+// Connections that are an io.Closer are closed once served, or on shutdown so their reads return.
+func AcceptLoop[C any](c *Conns, accept func() (C, error), serve func(C)) {
+	for {
+		conn, err := accept()
+		if errors.Is(err, net.ErrClosed) || c.Ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.Error().Err(err).Msgf("error accepting %s connection", c.name)
+			continue
+		}
+
+		closer, track := any(conn).(io.Closer)
+		if track {
+			c.mu.Lock()
+			c.conns[closer] = struct{}{}
+			c.mu.Unlock()
+		}
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			if track {
+				defer func() {
+					c.mu.Lock()
+					delete(c.conns, closer)
+					c.mu.Unlock()
+					closer.Close()
+				}()
+			}
+			serve(conn)
+		}()
+	}
+}
+
+// Shutdown cancels in flight requests, closes the listener and every connection, and waits for them to be served
+func (c *Conns) Shutdown(ctx context.Context) error {
+	// Cancelling first lets the accept loop tell the listener closing from an accept error
+	c.cancel()
+	err := c.listener.Close()
+	if err != nil {
+		return fmt.Errorf("error in listener.Close: %w", err)
+	}
+
+	c.mu.Lock()
+	for conn := range c.conns {
+		conn.Close()
+	}
+	c.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("error waiting for %s connections to close: %w", c.name, ctx.Err())
+	}
+}
+
+// CheckLeader returns nil if this node can issue timestamps from eh, otherwise ErrLeadershipNotReady or a
+// *NotLeaderError with the leader's address in advertiseAddrs
+func CheckLeader(eh *raft.EpochHost, advertiseAddrs map[uint64]string) error {
+	if eh.IssuesLocally() {
+		return nil
+	}
+	leader, available, err := eh.GetLeader()
+	if err != nil {
+		return fmt.Errorf("error in NodeHost.GetLeaderID: %w", err)
+	}
+	if !available {
+		return ErrLeadershipNotReady
+	}
+	if leader != utils.NodeID {
+		return &NotLeaderError{NodeID: utils.NodeID, Leader: leader, Addr: advertiseAddrs[leader]}
+	}
+	return nil
+}
+
+// Kind is how an error is reported to clients
+type Kind int
+
+const (
+	// KindInternal errors are logged, clients only get "internal error"
+	KindInternal Kind = iota
+	// KindTryAgain errors are transient, the request was shed and should be retried later
+	KindTryAgain
+	// KindInvalid errors are the request's fault, retrying it won't help
+	KindInvalid
+	// KindNotLeader errors are a *NotLeaderError, the request should be sent to the leader
+	KindNotLeader
+)
+
+// Reply returns how to report err to clients, and the message to report it with
+func Reply(err error) (Kind, string) {
+	var notLeader *NotLeaderError
+	switch {
+	case errors.As(err, &notLeader):
+		return KindNotLeader, err.Error()
+	case errors.Is(err, ErrLeadershipNotReady), errors.Is(err, raft.ErrOverloaded), errors.Is(err, raft.ErrQueueBudgetExceeded), errors.Is(err, raft.ErrQuotaExceeded):
+		return KindTryAgain, err.Error()
+	case errors.Is(err, raft.ErrCountExceedsLogical), errors.Is(err, raft.ErrCountExceedsQuota):
+		return KindInvalid, err.Error()
+	}
+	logger.Error().Err(err).Msg("error handling frontend request")
+	return KindInternal, "internal error"
+}
@@ -0,0 +1,57 @@
+package frontend
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/danthegoodman1/EpicEpoch/raft"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReply(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind Kind
+		msg  string
+	}{
+		{
+			name: "shed",
+			err:  fmt.Errorf("error in EpochHost.GetUniqueTimestamp: %w", raft.ErrOverloaded),
+			kind: KindTryAgain,
+			msg:  "error in EpochHost.GetUniqueTimestamp: " + raft.ErrOverloaded.Error(),
+		},
+		{
+			name: "no leader",
+			err:  ErrLeadershipNotReady,
+			kind: KindTryAgain,
+			msg:  "raft leadership not ready",
+		},
+		{
+			name: "invalid count",
+			err:  raft.ErrCountExceedsQuota,
+			kind: KindInvalid,
+			msg:  raft.ErrCountExceedsQuota.Error(),
+		},
+		{
+			name: "not leader",
+			err:  &NotLeaderError{NodeID: 2, Leader: 1, Addr: "localhost:6371"},
+			kind: KindNotLeader,
+			msg:  "node (2) is not the leader (1)",
+		},
+		{
+			name: "internal",
+			err:  errors.New("disk on fire"),
+			kind: KindInternal,
+			msg:  "internal error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, msg := Reply(tt.err)
+			assert.Equal(t, tt.kind, kind)
+			assert.Equal(t, tt.msg, msg)
+		})
+	}
+}
@@ -3,6 +3,7 @@ module github.com/danthegoodman1/EpicEpoch
 go 1.22
 
 require (
+	connectrpc.com/connect v1.16.1
 	github.com/UltimateTournament/backoff/v4 v4.2.1
 	github.com/cockroachdb/cockroach-go/v2 v2.3.5
 	github.com/go-playground/validator/v10 v10.11.1
@@ -24,6 +25,8 @@ require (
 	go.opentelemetry.io/otel/sdk v1.21.0
 	go.opentelemetry.io/otel/trace v1.21.0
 	golang.org/x/net v0.25.0
+	golang.org/x/time v0.5.0
+	google.golang.org/grpc v1.59.0
 	google.golang.org/protobuf v1.33.0
 )
 
@@ -97,10 +100,8 @@ require (
 	golang.org/x/sync v0.7.0 // indirect
 	golang.org/x/sys v0.20.0 // indirect
 	golang.org/x/text v0.15.0 // indirect
-	golang.org/x/time v0.5.0 // indirect
 	golang.org/x/tools v0.21.0 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20230822172742-b8732ec3820d // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20230822172742-b8732ec3820d // indirect
-	google.golang.org/grpc v1.59.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,95 @@
+package grpc_server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"connectrpc.com/connect"
+	apiv1 "github.com/danthegoodman1/EpicEpoch/proto/api/v1"
+	"github.com/danthegoodman1/EpicEpoch/proto/api/v1/apiv1connect"
+	"github.com/danthegoodman1/EpicEpoch/raft"
+	"google.golang.org/grpc/status"
+)
+
+// connectServer serves the HybridTimestampAPI over the Connect, gRPC-Web, and gRPC protocols with the
+// gRPC server's handlers, so browsers and edge workers get the same API from an HTTP listener
+type connectServer struct {
+	s *GRPCServer
+}
+
+// NewConnectHandler returns the handler for the HybridTimestampAPI over Connect, gRPC-Web, and gRPC,
+// and the path prefix to mount it on
+func NewConnectHandler(oracle *raft.Oracle) (string, http.Handler) {
+	return apiv1connect.NewHybridTimestampAPIHandler(&connectServer{s: &GRPCServer{Oracle: oracle}})
+}
+
+// unary calls a gRPC handler with the request message, converting its status error to a connect error
+// with the same code
+func unary[Req, Res any](ctx context.Context, req *connect.Request[Req], handler func(context.Context, *Req) (*Res, error)) (*connect.Response[Res], error) {
+	res, err := handler(ctx, req.Msg)
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			// gRPC and connect codes are the same
+			return nil, connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+		}
+		return nil, connect.NewError(connect.CodeUnknown, err)
+	}
+	return connect.NewResponse(res), nil
+}
+
+func (c *connectServer) GetTimestamp(ctx context.Context, req *connect.Request[apiv1.GetTimestampRequest]) (*connect.Response[apiv1.HybridTimestamp], error) {
+	return unary(ctx, req, c.s.GetTimestamp)
+}
+
+func (c *connectServer) GetTimestampRange(ctx context.Context, req *connect.Request[apiv1.GetTimestampRequest]) (*connect.Response[apiv1.TimestampRange], error) {
+	return unary(ctx, req, c.s.GetTimestampRange)
+}
+
+func (c *connectServer) GetReadTimestamp(ctx context.Context, req *connect.Request[apiv1.GetReadTimestampRequest]) (*connect.Response[apiv1.HybridTimestamp], error) {
+	return unary(ctx, req, c.s.GetReadTimestamp)
+}
+
+func (c *connectServer) GetSafeReadTimestamp(ctx context.Context, req *connect.Request[apiv1.GetSafeReadTimestampRequest]) (*connect.Response[apiv1.SafeReadTimestamp], error) {
+	return unary(ctx, req, c.s.GetSafeReadTimestamp)
+}
+
+func (c *connectServer) CommitWait(ctx context.Context, req *connect.Request[apiv1.CommitWaitRequest]) (*connect.Response[apiv1.CommitWaitResponse], error) {
+	return unary(ctx, req, c.s.CommitWait)
+}
+
+func (c *connectServer) Now(ctx context.Context, req *connect.Request[apiv1.NowRequest]) (*connect.Response[apiv1.TimeInterval], error) {
+	return unary(ctx, req, c.s.Now)
+}
+
+func (c *connectServer) NextSequence(ctx context.Context, req *connect.Request[apiv1.NextSequenceRequest]) (*connect.Response[apiv1.SequenceRange], error) {
+	return unary(ctx, req, c.s.NextSequence)
+}
+
+func (c *connectServer) AcquireLock(ctx context.Context, req *connect.Request[apiv1.AcquireLockRequest]) (*connect.Response[apiv1.LockLease], error) {
+	return unary(ctx, req, c.s.AcquireLock)
+}
+
+func (c *connectServer) RenewLock(ctx context.Context, req *connect.Request[apiv1.RenewLockRequest]) (*connect.Response[apiv1.LockLease], error) {
+	return unary(ctx, req, c.s.RenewLock)
+}
+
+func (c *connectServer) ReleaseLock(ctx context.Context, req *connect.Request[apiv1.ReleaseLockRequest]) (*connect.Response[apiv1.Empty], error) {
+	return unary(ctx, req, c.s.ReleaseLock)
+}
+
+func (c *connectServer) RegisterSafepoint(ctx context.Context, req *connect.Request[apiv1.RegisterSafepointRequest]) (*connect.Response[apiv1.Safepoint], error) {
+	return unary(ctx, req, c.s.RegisterSafepoint)
+}
+
+func (c *connectServer) RemoveSafepoint(ctx context.Context, req *connect.Request[apiv1.RemoveSafepointRequest]) (*connect.Response[apiv1.Safepoint], error) {
+	return unary(ctx, req, c.s.RemoveSafepoint)
+}
+
+func (c *connectServer) GetSafepoint(ctx context.Context, req *connect.Request[apiv1.Empty]) (*connect.Response[apiv1.Safepoint], error) {
+	return unary(ctx, req, c.s.GetSafepoint)
+}
+
+func (c *connectServer) GetIDs(ctx context.Context, req *connect.Request[apiv1.GetIDsRequest]) (*connect.Response[apiv1.IDs], error) {
+	return unary(ctx, req, c.s.GetIDs)
+}
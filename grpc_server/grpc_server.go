@@ -0,0 +1,514 @@
+package grpc_server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/danthegoodman1/EpicEpoch/gologger"
+	"github.com/danthegoodman1/EpicEpoch/id"
+	apiv1 "github.com/danthegoodman1/EpicEpoch/proto/api/v1"
+	"github.com/danthegoodman1/EpicEpoch/proto/pdpb"
+	"github.com/danthegoodman1/EpicEpoch/raft"
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"net"
+	"os"
+	"time"
+)
+
+var logger = gologger.NewLogger()
+
+type GRPCServer struct {
+	apiv1.UnimplementedHybridTimestampAPIServer
+	Oracle *raft.Oracle
+	server *grpc.Server
+}
+
+func StartGRPCServer(oracle *raft.Oracle) *GRPCServer {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", utils.GetEnvOrDefault("GRPC_PORT", "8090")))
+	if err != nil {
+		logger.Error().Err(err).Msg("error creating tcp listener, exiting")
+		os.Exit(1)
+	}
+	s := &GRPCServer{
+		Oracle: oracle,
+		server: grpc.NewServer(),
+	}
+	apiv1.RegisterHybridTimestampAPIServer(s.server, s)
+	if utils.PDAPI {
+		pd, err := newPDServer(oracle)
+		if err != nil {
+			logger.Error().Err(err).Msg("error creating PD server, exiting")
+			os.Exit(1)
+		}
+		pdpb.RegisterPDServer(s.server, pd)
+		// PD clients health check the members before forwarding TSO requests through them
+		healthpb.RegisterHealthServer(s.server, health.NewServer())
+	}
+
+	go func() {
+		logger.Info().Msg("starting grpc server on " + listener.Addr().String())
+		err := s.server.Serve(listener)
+		if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			logger.Error().Err(err).Msg("failed to start grpc server, exiting")
+			os.Exit(1)
+		}
+	}()
+
+	return s
+}
+
+func (s *GRPCServer) GetTimestamp(ctx context.Context, req *apiv1.GetTimestampRequest) (*apiv1.HybridTimestamp, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	eh, err := s.leaderShard(req.GetShard())
+	if err != nil {
+		return nil, err
+	}
+
+	count := max(int(req.GetCount()), 1)
+	if int64(count) > utils.TimestampMaxCount {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("count must be <= %d", utils.TimestampMaxCount))
+	}
+	tsReq, err := timestampRequest(eh, req, count)
+	if err != nil {
+		return nil, err
+	}
+	reserved, err := eh.GetUniqueTimestamp(ctx, tsReq)
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in EpochHost.GetUniqueTimestamp: %w", err))
+	}
+
+	return &apiv1.HybridTimestamp{Timestamp: expandedBytes(eh, reserved)}, nil
+}
+
+func (s *GRPCServer) GetTimestampRange(ctx context.Context, req *apiv1.GetTimestampRequest) (*apiv1.TimestampRange, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	eh, err := s.leaderShard(req.GetShard())
+	if err != nil {
+		return nil, err
+	}
+
+	count := max(int(req.GetCount()), 1)
+	if int64(count) > utils.TimestampMaxRangeCount {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("count must be <= %d", utils.TimestampMaxRangeCount))
+	}
+	tsReq, err := timestampRequest(eh, req, count)
+	if err != nil {
+		return nil, err
+	}
+	reserved, err := eh.GetUniqueTimestamp(ctx, tsReq)
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in EpochHost.GetUniqueTimestamp: %w", err))
+	}
+
+	return &apiv1.TimestampRange{
+		Epoch:      reserved.Epoch,
+		StartIndex: reserved.StartIndex,
+		Count:      reserved.Count,
+	}, nil
+}
+
+func (s *GRPCServer) GetReadTimestamp(ctx context.Context, req *apiv1.GetReadTimestampRequest) (*apiv1.HybridTimestamp, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	eh, err := s.leaderShard(req.GetShard())
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := eh.GetReadTimestamp(ctx, priorityFromProto(req.GetPriority()))
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in EpochHost.GetReadTimestamp: %w", err))
+	}
+
+	return &apiv1.HybridTimestamp{Timestamp: expandedBytes(eh, timestamp.Range{Epoch: ts.Epoch, StartIndex: ts.Index, Count: 1})}, nil
+}
+
+func (s *GRPCServer) GetSafeReadTimestamp(ctx context.Context, req *apiv1.GetSafeReadTimestampRequest) (*apiv1.SafeReadTimestamp, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	eh, err := s.shard(req.GetShard())
+	if err != nil {
+		return nil, err
+	}
+
+	ts, staleness, err := eh.GetSafeReadTimestamp(ctx, req.GetReadIndex())
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in EpochHost.GetSafeReadTimestamp: %w", err))
+	}
+
+	return &apiv1.SafeReadTimestamp{Timestamp: expandedBytes(eh, timestamp.Range{Epoch: ts.Epoch, StartIndex: ts.Index, Count: 1}), StalenessMs: uint64(staleness.Milliseconds())}, nil
+}
+
+func (s *GRPCServer) CommitWait(ctx context.Context, req *apiv1.CommitWaitRequest) (*apiv1.CommitWaitResponse, error) {
+	eh, err := s.leaderShard(req.GetShard())
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := fromBytes(eh, req.GetTimestamp())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid timestamp: %s", err))
+	}
+
+	timeoutMS := utils.CommitWaitMaxMS
+	if t := int64(req.GetTimeoutMs()); t > 0 {
+		timeoutMS = min(t, utils.CommitWaitMaxMS)
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Millisecond*time.Duration(timeoutMS))
+	defer cancel()
+	epoch, err := eh.CommitWait(ctx, ts)
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in EpochHost.CommitWait: %w", err))
+	}
+
+	return &apiv1.CommitWaitResponse{Epoch: epoch}, nil
+}
+
+func (s *GRPCServer) Now(ctx context.Context, req *apiv1.NowRequest) (*apiv1.TimeInterval, error) {
+	eh, err := s.shard(req.GetShard())
+	if err != nil {
+		return nil, err
+	}
+
+	interval, err := eh.Now()
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in EpochHost.Now: %w", err))
+	}
+
+	return &apiv1.TimeInterval{
+		Earliest:      interval.Earliest,
+		Latest:        interval.Latest,
+		Epoch:         interval.Epoch,
+		UncertaintyNs: uint64(interval.Uncertainty),
+	}, nil
+}
+
+func (s *GRPCServer) NextSequence(ctx context.Context, req *apiv1.NextSequenceRequest) (*apiv1.SequenceRange, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	meta, err := s.metaLeader()
+	if err != nil {
+		return nil, err
+	}
+
+	count := max(req.GetCount(), 1)
+	if count > uint64(utils.TimestampMaxRangeCount) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("count must be <= %d", utils.TimestampMaxRangeCount))
+	}
+	seq, err := meta.NextSequence(ctx, req.GetName(), count)
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in MetaHost.NextSequence: %w", err))
+	}
+
+	return &apiv1.SequenceRange{Start: seq.Start, Count: seq.Count}, nil
+}
+
+func (s *GRPCServer) AcquireLock(ctx context.Context, req *apiv1.AcquireLockRequest) (*apiv1.LockLease, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if err := s.issuerLeader(); err != nil {
+		return nil, err
+	}
+	ttl, err := lockTTL(req.GetTtlMs())
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := s.Oracle.AcquireLock(ctx, req.GetName(), req.GetOwner(), ttl)
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in Oracle.AcquireLock: %w", err))
+	}
+
+	return lockLease(lock), nil
+}
+
+func (s *GRPCServer) RenewLock(ctx context.Context, req *apiv1.RenewLockRequest) (*apiv1.LockLease, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if err := s.issuerLeader(); err != nil {
+		return nil, err
+	}
+	ttl, err := lockTTL(req.GetTtlMs())
+	if err != nil {
+		return nil, err
+	}
+	token, err := timestamp.FromBytes(req.GetToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid token: %s", err))
+	}
+
+	lock, err := s.Oracle.RenewLock(ctx, req.GetName(), req.GetOwner(), token, ttl)
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in Oracle.RenewLock: %w", err))
+	}
+
+	return lockLease(lock), nil
+}
+
+func (s *GRPCServer) ReleaseLock(ctx context.Context, req *apiv1.ReleaseLockRequest) (*apiv1.Empty, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if err := s.issuerLeader(); err != nil {
+		return nil, err
+	}
+	token, err := timestamp.FromBytes(req.GetToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid token: %s", err))
+	}
+
+	err = s.Oracle.ReleaseLock(ctx, req.GetName(), req.GetOwner(), token)
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in Oracle.ReleaseLock: %w", err))
+	}
+
+	return &apiv1.Empty{}, nil
+}
+
+func (s *GRPCServer) RegisterSafepoint(ctx context.Context, req *apiv1.RegisterSafepointRequest) (*apiv1.Safepoint, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if err := s.issuerLeader(); err != nil {
+		return nil, err
+	}
+	if req.GetTtlMs() < 1 || req.GetTtlMs() > uint64(utils.SafepointMaxTTLMS) {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("ttl_ms must be between 1 and %d", utils.SafepointMaxTTLMS))
+	}
+	ts, err := timestamp.FromBytes(req.GetTimestamp())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid timestamp: %s", err))
+	}
+
+	sp, err := s.Oracle.RegisterSafepoint(ctx, req.GetService(), ts, time.Millisecond*time.Duration(req.GetTtlMs()))
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in Oracle.RegisterSafepoint: %w", err))
+	}
+
+	return &apiv1.Safepoint{Safepoint: sp.Safepoint.Bytes()}, nil
+}
+
+func (s *GRPCServer) RemoveSafepoint(ctx context.Context, req *apiv1.RemoveSafepointRequest) (*apiv1.Safepoint, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if err := s.issuerLeader(); err != nil {
+		return nil, err
+	}
+
+	sp, err := s.Oracle.RemoveSafepoint(ctx, req.GetService())
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in Oracle.RemoveSafepoint: %w", err))
+	}
+
+	return &apiv1.Safepoint{Safepoint: sp.Safepoint.Bytes()}, nil
+}
+
+func (s *GRPCServer) GetSafepoint(ctx context.Context, _ *apiv1.Empty) (*apiv1.Safepoint, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	sp, err := s.Oracle.GetSafepoint(ctx)
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in Oracle.GetSafepoint: %w", err))
+	}
+
+	return &apiv1.Safepoint{Safepoint: sp.Safepoint.Bytes()}, nil
+}
+
+func (s *GRPCServer) GetIDs(ctx context.Context, req *apiv1.GetIDsRequest) (*apiv1.IDs, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	eh, err := s.leaderShard(req.GetShard())
+	if err != nil {
+		return nil, err
+	}
+
+	count := max(int(req.GetCount()), 1)
+	ids, err := eh.GetIDs(ctx, idFormatFromProto(req.GetFormat()), count, priorityFromProto(req.GetPriority()))
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in EpochHost.GetIDs: %w", err))
+	}
+
+	return &apiv1.IDs{Ids: ids}, nil
+}
+
+func lockTTL(ttlMs uint64) (time.Duration, error) {
+	if ttlMs < 1 || ttlMs > uint64(utils.LockMaxTTLMS) {
+		return 0, status.Error(codes.InvalidArgument, fmt.Sprintf("ttl_ms must be between 1 and %d", utils.LockMaxTTLMS))
+	}
+	return time.Millisecond * time.Duration(ttlMs), nil
+}
+
+func lockLease(lock raft.Lock) *apiv1.LockLease {
+	return &apiv1.LockLease{Owner: lock.Owner, Token: lock.Token.Bytes(), ExpiresAt: lock.ExpiresAt}
+}
+
+// expandedBytes returns every timestamp in the range concatenated, 8 bytes each in the packed layout and 16 otherwise
+func expandedBytes(eh *raft.EpochHost, r timestamp.Range) []byte {
+	if packing := eh.Packing(); packing != nil {
+		return packing.ExpandedBytes(r)
+	}
+	return r.ExpandedBytes()
+}
+
+// fromBytes decodes a 16 byte timestamp, or an 8 byte packed one in the packed layout
+func fromBytes(eh *raft.EpochHost, b []byte) (timestamp.Timestamp, error) {
+	if packing := eh.Packing(); packing != nil && len(b) == timestamp.PackedSize {
+		return packing.FromBytes(b)
+	}
+	return timestamp.FromBytes(b)
+}
+
+func timestampRequest(eh *raft.EpochHost, req *apiv1.GetTimestampRequest, count int) (raft.TimestampRequest, error) {
+	tsReq := raft.TimestampRequest{Count: count, Priority: priorityFromProto(req.GetPriority())}
+	if len(req.GetMin()) > 0 {
+		minTS, err := fromBytes(eh, req.GetMin())
+		if err != nil {
+			return tsReq, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid min: %s", err))
+		}
+		tsReq.Min = minTS
+	}
+	return tsReq, nil
+}
+
+// shard returns the selected shard, defaulting to the first shard of the default namespace
+func (s *GRPCServer) shard(sel *apiv1.ShardSelector) (*raft.EpochHost, error) {
+	eh, err := s.Oracle.ShardFor(sel.GetNamespace(), uint64(sel.GetShard()), sel.GetKey())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return eh, nil
+}
+
+// leaderShard returns the selected shard, rejecting the request if this node is not its raft leader, clients should
+// refresh membership and retry. In the bounded issue mode every node issues timestamps.
+func (s *GRPCServer) leaderShard(sel *apiv1.ShardSelector) (*raft.EpochHost, error) {
+	eh, err := s.shard(sel)
+	if err != nil {
+		return nil, err
+	}
+
+	if eh.IssuesLocally() {
+		return eh, nil
+	}
+
+	leader, available, err := eh.GetLeader()
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in NodeHost.GetLeaderID: %w", err))
+	}
+
+	if !available {
+		return nil, status.Error(codes.Unavailable, "raft leadership not ready")
+	}
+
+	if leader != utils.NodeID {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("node (%d) is not the leader (%d)", utils.NodeID, leader))
+	}
+
+	return eh, nil
+}
+
+// issuerLeader rejects the request if this node is not the leader of the shard that drives lease expiries.
+// This is also required in the bounded issue mode, so that lease expiries are judged by a single clock.
+func (s *GRPCServer) issuerLeader() error {
+	eh := s.Oracle.Issuer()
+	leader, available, err := eh.GetLeader()
+	if err != nil {
+		return grpcError(fmt.Errorf("error in NodeHost.GetLeaderID: %w", err))
+	}
+
+	if !available {
+		return status.Error(codes.Unavailable, "raft leadership not ready")
+	}
+
+	if leader != utils.NodeID {
+		return status.Error(codes.FailedPrecondition, fmt.Sprintf("node (%d) is not the leader (%d)", utils.NodeID, leader))
+	}
+
+	return nil
+}
+
+// metaLeader returns the meta raft group, rejecting the request if this node is not its leader
+func (s *GRPCServer) metaLeader() (*raft.MetaHost, error) {
+	meta := s.Oracle.Meta()
+	leader, available, err := meta.GetLeader()
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in MetaHost.GetLeader: %w", err))
+	}
+
+	if !available {
+		return nil, status.Error(codes.Unavailable, "raft leadership not ready")
+	}
+
+	if leader != utils.NodeID {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("node (%d) is not the meta leader (%d)", utils.NodeID, leader))
+	}
+
+	return meta, nil
+}
+
+func priorityFromProto(p apiv1.Priority) raft.Priority {
+	switch p {
+	case apiv1.Priority_PRIORITY_HIGH:
+		return raft.PriorityHigh
+	case apiv1.Priority_PRIORITY_LOW:
+		return raft.PriorityLow
+	default:
+		return raft.PriorityNormal
+	}
+}
+
+func idFormatFromProto(f apiv1.IDFormat) id.Format {
+	switch f {
+	case apiv1.IDFormat_ID_FORMAT_ULID:
+		return id.FormatULID
+	case apiv1.IDFormat_ID_FORMAT_SNOWFLAKE:
+		return id.FormatSnowflake
+	default:
+		return id.FormatUUIDv7
+	}
+}
+
+// grpcError converts an error into a gRPC status error with an appropriate code
+func grpcError(err error) error {
+	switch {
+	case errors.Is(err, raft.ErrOverloaded), errors.Is(err, raft.ErrQueueBudgetExceeded), errors.Is(err, raft.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, raft.ErrNoAppliedEpoch), errors.Is(err, id.ErrDoesNotFit):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, raft.ErrMinTooFarAhead), errors.Is(err, raft.ErrInvalidSequence), errors.Is(err, raft.ErrInvalidLock), errors.Is(err, raft.ErrInvalidService), errors.Is(err, raft.ErrInvalidIDCount), errors.Is(err, raft.ErrIDNamespace), errors.Is(err, raft.ErrCountExceedsLogical), errors.Is(err, raft.ErrCountExceedsQuota):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, raft.ErrLockHeld):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, raft.ErrLockLost), errors.Is(err, raft.ErrBelowSafepoint):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	}
+	logger.Error().Err(err).Msg("unhandled grpc error")
+	return status.Error(codes.Internal, "Something went wrong internally, an error has been logged")
+}
+
+func (s *GRPCServer) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return fmt.Errorf("error gracefully stopping grpc server: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,153 @@
+package grpc_server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/proto/pdpb"
+	"github.com/danthegoodman1/EpicEpoch/raft"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// pdClusterID is the cluster ID PD clients see in every response header
+	pdClusterID = raft.ClusterID
+	// pdLogicalBits is how many logical bits PD clients compose timestamps with
+	pdLogicalBits = 18
+	// pdGlobalDCLocation is the dc_location of PD's global TSO, the only one served
+	pdGlobalDCLocation = "global"
+)
+
+// PDServer serves the TSO subset of PD's gRPC API from the first shard of the default namespace,
+// so TiKV/TiDB-style clients can use EpicEpoch as their timestamp oracle
+type PDServer struct {
+	pdpb.UnimplementedPDServer
+	Oracle *raft.Oracle
+	// clientURLs are the URLs PD clients reach each node at, by node ID
+	clientURLs map[uint64]string
+}
+
+// newPDServer checks that the oracle issues timestamps PD clients can compose
+func newPDServer(oracle *raft.Oracle) (*PDServer, error) {
+	packing := oracle.Issuer().Packing()
+	if packing == nil || packing.LogicalBits != pdLogicalBits {
+		return nil, fmt.Errorf("the PD API requires TIMESTAMP_LAYOUT=packed with PACKED_LOGICAL_BITS=%d", pdLogicalBits)
+	}
+
+	clientURLs, err := utils.ParseNodeAddrs(utils.PDClientURLs)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing PD_CLIENT_URLS: %w", err)
+	}
+
+	return &PDServer{Oracle: oracle, clientURLs: clientURLs}, nil
+}
+
+func (s *PDServer) header() *pdpb.ResponseHeader {
+	return &pdpb.ResponseHeader{ClusterId: pdClusterID}
+}
+
+func (s *PDServer) member(m raft.Member) *pdpb.Member {
+	member := &pdpb.Member{
+		Name:       fmt.Sprintf("epicepoch-%d", m.NodeID),
+		MemberId:   m.NodeID,
+		PeerUrls:   []string{m.Addr},
+		DcLocation: pdGlobalDCLocation,
+	}
+	if url, ok := s.clientURLs[m.NodeID]; ok {
+		member.ClientUrls = []string{url}
+	}
+	return member
+}
+
+func (s *PDServer) GetMembers(ctx context.Context, _ *pdpb.GetMembersRequest) (*pdpb.GetMembersResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	membership, err := s.Oracle.GetMembership(ctx, raft.DefaultNamespace)
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in Oracle.GetMembership: %w", err))
+	}
+
+	res := &pdpb.GetMembersResponse{Header: s.header(), Leader: s.member(membership.Leader)}
+	for _, m := range membership.Members {
+		res.Members = append(res.Members, s.member(m))
+	}
+	// TSO is served by the raft leader, which PD clients know as the etcd leader too
+	res.EtcdLeader = res.Leader
+
+	return res, nil
+}
+
+// GetClusterInfo reports the PD service mode, where the members from GetMembers serve TSO themselves
+func (s *PDServer) GetClusterInfo(_ context.Context, _ *pdpb.GetClusterInfoRequest) (*pdpb.GetClusterInfoResponse, error) {
+	return &pdpb.GetClusterInfoResponse{
+		Header:       s.header(),
+		ServiceModes: []pdpb.ServiceMode{pdpb.ServiceMode_PD_SVC_MODE},
+	}, nil
+}
+
+// Tso serves a stream of TSO requests in order. Each response is the largest of count timestamps,
+// which are the count logical values up to and including it.
+func (s *PDServer) Tso(stream pdpb.PD_TsoServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		res, err := s.tso(stream.Context(), req)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(res); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *PDServer) tso(ctx context.Context, req *pdpb.TsoRequest) (*pdpb.TsoResponse, error) {
+	if cid := req.GetHeader().GetClusterId(); cid != 0 && cid != pdClusterID {
+		return nil, status.Error(codes.FailedPrecondition, fmt.Sprintf("mismatched cluster ID, need %d but got %d", pdClusterID, cid))
+	}
+	if dc := req.GetDcLocation(); dc != "" && dc != pdGlobalDCLocation {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("only the %s dc_location is served, got %s", pdGlobalDCLocation, dc))
+	}
+	if req.GetCount() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "tso count should be positive")
+	}
+
+	eh := s.Oracle.Issuer()
+	leader, available, err := eh.GetLeader()
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in NodeHost.GetLeaderID: %w", err))
+	}
+	if !available || leader != utils.NodeID {
+		// PD clients reconnect to the leader from GetMembers when the stream fails
+		return nil, status.Error(codes.Unavailable, fmt.Sprintf("node (%d) is not the leader (%d)", utils.NodeID, leader))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	reserved, err := eh.GetUniqueTimestamp(ctx, raft.TimestampRequest{Count: int(req.GetCount()), Priority: raft.PriorityNormal})
+	if err != nil {
+		return nil, grpcError(fmt.Errorf("error in EpochHost.GetUniqueTimestamp: %w", err))
+	}
+
+	last := reserved.Last()
+	return &pdpb.TsoResponse{
+		Header: s.header(),
+		Count:  req.GetCount(),
+		Timestamp: &pdpb.Timestamp{
+			Physical: int64(last.Epoch / 1e6),
+			Logical:  int64(last.Index),
+		},
+	}, nil
+}
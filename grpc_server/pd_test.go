@@ -0,0 +1,143 @@
+//go:build integration
+
+package grpc_server
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/proto/pdpb"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// TestPDTso follows the PD client's flow (GetMembers from every member, GetClusterInfo and a health check from the
+// leader, then batched Tso streams to the leader) against a local cluster started with PD_API=1,
+// TIMESTAMP_LAYOUT=packed, and PD_CLIENT_URLS, using the generated pdpb stub. PD_ENDPOINTS is a comma separated
+// list of the gRPC addresses of the nodes, e.g.
+//
+//	PD_ENDPOINTS=localhost:8091,localhost:8092,localhost:8093 go test -tags integration ./grpc_server -run TestPDTso
+func TestPDTso(t *testing.T) {
+	endpoints := os.Getenv("PD_ENDPOINTS")
+	if endpoints == "" {
+		t.Skip("PD_ENDPOINTS is not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	// Like the PD client, find the leader from any member
+	var leaderURL string
+	for _, endpoint := range strings.Split(endpoints, ",") {
+		members, err := pdClient(t, endpoint).GetMembers(ctx, &pdpb.GetMembersRequest{Header: &pdpb.RequestHeader{ClusterId: pdClusterID}})
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.Equal(t, uint64(pdClusterID), members.GetHeader().GetClusterId())
+		if !assert.NotEmpty(t, members.GetLeader().GetClientUrls()) {
+			return
+		}
+		if leaderURL != "" {
+			assert.Equal(t, leaderURL, members.GetLeader().GetClientUrls()[0], "members disagree on the leader")
+		}
+		leaderURL = members.GetLeader().GetClientUrls()[0]
+	}
+	leaderConn := dial(t, strings.TrimPrefix(leaderURL, "http://"))
+	leader := pdpb.NewPDClient(leaderConn)
+
+	// Then it checks the service mode with the leader, only the PD mode serves TSO from the members
+	info, err := leader.GetClusterInfo(ctx, &pdpb.GetClusterInfoRequest{})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, uint64(pdClusterID), info.GetHeader().GetClusterId())
+	assert.Equal(t, []pdpb.ServiceMode{pdpb.ServiceMode_PD_SVC_MODE}, info.GetServiceModes())
+	assert.Empty(t, info.GetTsoUrls())
+
+	health, err := healthpb.NewHealthClient(leaderConn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, health.GetStatus())
+
+	// Concurrent streams, each sending batches of requests before reading the responses like the PD client does
+	const streams, batches, batchSize = 8, 50, 4
+	var mu sync.Mutex
+	seen := map[uint64]struct{}{}
+	var wg sync.WaitGroup
+	for i := 0; i < streams; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stream, err := leader.Tso(ctx)
+			if !assert.Nil(t, err) {
+				return
+			}
+			var prev uint64
+			for b := 0; b < batches; b++ {
+				for r := 0; r < batchSize; r++ {
+					err := stream.Send(&pdpb.TsoRequest{Header: &pdpb.RequestHeader{ClusterId: pdClusterID}, Count: uint32(r + 1), DcLocation: pdGlobalDCLocation})
+					if !assert.Nil(t, err) {
+						return
+					}
+				}
+				for r := 0; r < batchSize; r++ {
+					res, err := stream.Recv()
+					if !assert.Nil(t, err) {
+						return
+					}
+					assert.Equal(t, uint32(r+1), res.GetCount())
+
+					// The response is the largest of count timestamps, the first is count - 1 logical values before it
+					last := uint64(res.GetTimestamp().GetPhysical())<<pdLogicalBits | uint64(res.GetTimestamp().GetLogical())
+					first := last - uint64(res.GetCount()) + 1
+					assert.Greater(t, first, prev, "timestamps went backwards within a stream")
+					prev = last
+
+					mu.Lock()
+					for ts := first; ts <= last; ts++ {
+						_, dup := seen[ts]
+						assert.False(t, dup, "duplicate timestamp %d", ts)
+						seen[ts] = struct{}{}
+					}
+					mu.Unlock()
+				}
+			}
+			assert.Nil(t, stream.CloseSend())
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, streams*batches*(1+2+3+4))
+
+	// A follower rejects the stream, so the client goes back to GetMembers
+	for _, endpoint := range strings.Split(endpoints, ",") {
+		if "http://"+endpoint == leaderURL {
+			continue
+		}
+		stream, err := pdClient(t, endpoint).Tso(ctx)
+		if !assert.Nil(t, err) {
+			return
+		}
+		assert.Nil(t, stream.Send(&pdpb.TsoRequest{Header: &pdpb.RequestHeader{ClusterId: pdClusterID}, Count: 1}))
+		_, err = stream.Recv()
+		assert.NotNil(t, err)
+	}
+}
+
+func pdClient(t *testing.T, addr string) pdpb.PDClient {
+	return pdpb.NewPDClient(dial(t, addr))
+}
+
+func dial(t *testing.T, addr string) *grpc.ClientConn {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
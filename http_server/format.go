@@ -0,0 +1,152 @@
+package http_server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	apiv1 "github.com/danthegoodman1/EpicEpoch/proto/api/v1"
+	"github.com/danthegoodman1/EpicEpoch/raft"
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/protobuf/proto"
+)
+
+// timestampFormat is a representation of timestamps that a client can ask for.
+// Every format represents exactly the same value as the binary form.
+type timestampFormat string
+
+const (
+	formatBinary  timestampFormat = "binary"
+	formatJSON    timestampFormat = "json"
+	formatHex     timestampFormat = "hex"
+	formatBase32  timestampFormat = "base32"
+	formatDecimal timestampFormat = "decimal"
+	formatProto   timestampFormat = "proto"
+
+	mimeProtobuf = "application/x-protobuf"
+)
+
+// negotiateFormat picks the timestamp format from the format query param, falling back to the Accept header
+func negotiateFormat(c echo.Context) (timestampFormat, error) {
+	if f := c.QueryParam("format"); f != "" {
+		switch format := timestampFormat(f); format {
+		case formatBinary, formatJSON, formatHex, formatBase32, formatDecimal, formatProto:
+			return format, nil
+		}
+		return "", fmt.Errorf("invalid format param, must be one of binary, json, hex, base32, decimal, or proto")
+	}
+
+	accept := c.Request().Header.Get(echo.HeaderAccept)
+	switch {
+	case strings.Contains(accept, echo.MIMEApplicationJSON):
+		return formatJSON, nil
+	case strings.Contains(accept, mimeProtobuf), strings.Contains(accept, echo.MIMEApplicationProtobuf):
+		return formatProto, nil
+	}
+	return formatBinary, nil
+}
+
+// writeTimestamps writes every timestamp in the reserved range in the requested format, packed if packing is not nil.
+// Text formats are newline separated, one timestamp per line.
+func writeTimestamps(c echo.Context, format timestampFormat, reserved timestamp.Range, packing *timestamp.Packing) error {
+	if packing != nil {
+		return writePacked(c, format, reserved, packing)
+	}
+	switch format {
+	case formatJSON:
+		timestamps := make([]timestamp.Timestamp, 0, reserved.Count)
+		for it := reserved.Iter(); ; {
+			ts, ok := it.Next()
+			if !ok {
+				break
+			}
+			timestamps = append(timestamps, ts)
+		}
+		return c.JSON(http.StatusOK, timestamps)
+	case formatHex, formatBase32, formatDecimal:
+		var sb strings.Builder
+		for it := reserved.Iter(); ; {
+			ts, ok := it.Next()
+			if !ok {
+				break
+			}
+			switch format {
+			case formatHex:
+				sb.WriteString(ts.Hex())
+			case formatBase32:
+				sb.WriteString(ts.Base32())
+			case formatDecimal:
+				sb.WriteString(ts.Decimal())
+			}
+			sb.WriteByte('\n')
+		}
+		return c.String(http.StatusOK, sb.String())
+	case formatProto:
+		return writeProto(c, &apiv1.HybridTimestamp{Timestamp: reserved.ExpandedBytes()})
+	}
+	return c.Blob(http.StatusOK, echo.MIMEOctetStream, reserved.ExpandedBytes())
+}
+
+// writePacked writes every timestamp in the reserved range packed into a uint64, 8 bytes each in the binary forms.
+// JSON is an array of decimal strings, because JavaScript can't represent a uint64.
+func writePacked(c echo.Context, format timestampFormat, reserved timestamp.Range, packing *timestamp.Packing) error {
+	switch format {
+	case formatJSON, formatHex, formatBase32, formatDecimal:
+		encoded := make([]string, 0, reserved.Count)
+		for it := reserved.Iter(); ; {
+			ts, ok := it.Next()
+			if !ok {
+				break
+			}
+			switch format {
+			case formatHex:
+				encoded = append(encoded, packing.Hex(ts))
+			case formatBase32:
+				encoded = append(encoded, packing.Base32(ts))
+			default:
+				encoded = append(encoded, packing.Decimal(ts))
+			}
+		}
+		if format == formatJSON {
+			return c.JSON(http.StatusOK, encoded)
+		}
+		return c.String(http.StatusOK, strings.Join(encoded, "\n")+"\n")
+	case formatProto:
+		return writeProto(c, &apiv1.HybridTimestamp{Timestamp: packing.ExpandedBytes(reserved)})
+	}
+	return c.Blob(http.StatusOK, echo.MIMEOctetStream, packing.ExpandedBytes(reserved))
+}
+
+// parseTimestamp decodes a timestamp from any of the text formats, including the packed ones in the packed layout
+func parseTimestamp(eh *raft.EpochHost, s string) (timestamp.Timestamp, error) {
+	if packing := eh.Packing(); packing != nil {
+		return packing.Parse(s)
+	}
+	return timestamp.Parse(s)
+}
+
+// writeRange writes the compact form of the reserved range, which only has binary, JSON, and protobuf forms
+func writeRange(c echo.Context, format timestampFormat, reserved timestamp.Range) error {
+	switch format {
+	case formatBinary:
+		return c.Blob(http.StatusOK, echo.MIMEOctetStream, reserved.Bytes())
+	case formatJSON:
+		return c.JSON(http.StatusOK, reserved)
+	case formatProto:
+		return writeProto(c, &apiv1.TimestampRange{
+			Epoch:      reserved.Epoch,
+			StartIndex: reserved.StartIndex,
+			Count:      reserved.Count,
+		})
+	}
+	return c.String(http.StatusNotAcceptable, fmt.Sprintf("format %s is not supported in range mode", format))
+}
+
+func writeProto(c echo.Context, m proto.Message) error {
+	b, err := proto.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("error in proto.Marshal: %w", err)
+	}
+	return c.Blob(http.StatusOK, mimeProtobuf, b)
+}
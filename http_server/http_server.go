@@ -2,18 +2,13 @@ package http_server
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/elliptic"
-	"crypto/rand"
 	"crypto/tls"
-	"crypto/x509"
-	"crypto/x509/pkix"
-	"encoding/pem"
 	"errors"
 	"fmt"
+	"github.com/danthegoodman1/EpicEpoch/grpc_server"
 	"github.com/danthegoodman1/EpicEpoch/raft"
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
 	"github.com/quic-go/quic-go/http3"
-	"math/big"
 	"net"
 	"net/http"
 	"os"
@@ -33,7 +28,7 @@ var logger = gologger.NewLogger()
 
 type HTTPServer struct {
 	Echo       *echo.Echo
-	EpochHost  *raft.EpochHost
+	Oracle     *raft.Oracle
 	quicServer *http3.Server
 }
 
@@ -41,15 +36,15 @@ type CustomValidator struct {
 	validator *validator.Validate
 }
 
-func StartHTTPServer(epochHost *raft.EpochHost) *HTTPServer {
+func StartHTTPServer(oracle *raft.Oracle) *HTTPServer {
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", utils.GetEnvOrDefault("HTTP_PORT", "8080")))
 	if err != nil {
 		logger.Error().Err(err).Msg("error creating tcp listener, exiting")
 		os.Exit(1)
 	}
 	s := &HTTPServer{
-		Echo:      echo.New(),
-		EpochHost: epochHost,
+		Echo:   echo.New(),
+		Oracle: oracle,
 	}
 	s.Echo.HideBanner = true
 	s.Echo.HidePort = true
@@ -58,13 +53,37 @@ func StartHTTPServer(epochHost *raft.EpochHost) *HTTPServer {
 
 	s.Echo.Use(CreateReqContext)
 	s.Echo.Use(LoggerMiddleware)
-	s.Echo.Use(middleware.CORS())
+	s.Echo.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		// gRPC-Web clients read the status from these headers
+		ExposeHeaders: []string{"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin"},
+	}))
 	s.Echo.Validator = &CustomValidator{validator: validator.New()}
 
 	s.Echo.GET("/up", s.UpCheck)
 	s.Echo.GET("/ready", s.ReadyCheck)
 	s.Echo.GET("/timestamp", s.GetTimestamp)
+	s.Echo.GET("/read-timestamp", s.GetReadTimestamp)
+	s.Echo.GET("/safe-read-timestamp", s.GetSafeReadTimestamp)
+	s.Echo.GET("/wait", s.CommitWait)
+	s.Echo.GET("/now", s.Now)
+	s.Echo.GET("/id", s.GetIDs)
+	s.Echo.GET("/sequence/:name", s.NextSequence)
+	s.Echo.GET("/locks/:name", s.GetLock)
+	s.Echo.PUT("/locks/:name", s.AcquireLock)
+	s.Echo.PUT("/locks/:name/renew", s.RenewLock)
+	s.Echo.DELETE("/locks/:name", s.ReleaseLock)
+	s.Echo.GET("/safepoint", s.GetSafepoint)
+	s.Echo.PUT("/safepoint/:service", s.RegisterSafepoint)
+	s.Echo.DELETE("/safepoint/:service", s.RemoveSafepoint)
 	s.Echo.GET("/membership", s.GetMembership)
+	s.Echo.GET("/config/batching", s.GetBatching)
+	s.Echo.PUT("/config/batching", s.SetBatching)
+	s.Echo.GET("/config/issuing", s.GetIssuing)
+	s.Echo.PUT("/config/issuing", s.SetIssuing)
+
+	// The HybridTimestampAPI over Connect, gRPC-Web, and gRPC, with the gRPC server's handlers
+	connectPath, connectHandler := grpc_server.NewConnectHandler(oracle)
+	s.Echo.Any(connectPath+"*", echo.WrapHandler(connectHandler))
 
 	s.Echo.Listener = listener
 	go func() {
@@ -78,7 +97,7 @@ func StartHTTPServer(epochHost *raft.EpochHost) *HTTPServer {
 
 	// Start http/3 server
 	go func() {
-		tlsCert, err := generateTLSCert()
+		tlsCert, err := utils.GenerateTLSCert()
 		if err != nil {
 			logger.Fatal().Err(err).Msg("failed to generate self-signed cert")
 		}
@@ -140,8 +159,13 @@ func (s *HTTPServer) UpCheck(c echo.Context) error {
 func (s *HTTPServer) ReadyCheck(c echo.Context) error {
 	ctx := c.Request().Context()
 	logger := zerolog.Ctx(ctx)
+	eh, err := s.shard(c)
+	if err != nil {
+		return err
+	}
+
 	// Verify that raft leadership information is available
-	leader, available, err := s.EpochHost.GetLeader()
+	leader, available, err := eh.GetLeader()
 	if err != nil {
 		return fmt.Errorf("error in NodeHost.GetLeaderID: %w", err)
 	}
@@ -160,19 +184,20 @@ func (s *HTTPServer) ReadyCheck(c echo.Context) error {
 func (s *HTTPServer) GetTimestamp(c echo.Context) error {
 	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second)
 	defer cancel()
-	// Verify that this is the raft leader
-	leader, available, err := s.EpochHost.GetLeader()
+	eh, err := s.leaderShard(c)
 	if err != nil {
-		return fmt.Errorf("error in NodeHost.GetLeaderID: %w", err)
-	}
-
-	if !available {
-		return c.String(http.StatusInternalServerError, "raft leadership not ready")
+		return err
 	}
 
-	if leader != utils.NodeID {
-		return c.String(http.StatusConflict, fmt.Sprintf("node (%d) is not the leader (%d)", utils.NodeID, leader))
+	// Range mode returns the compact (epoch, startIndex, count) instead of every timestamp
+	rangeMode := false
+	if r := c.QueryParam("range"); r != "" {
+		rangeMode, err = strconv.ParseBool(r)
+		if err != nil {
+			return c.String(http.StatusBadRequest, "invalid range param, must be a boolean if provided")
+		}
 	}
+	maxCount := utils.IfElse(rangeMode, utils.TimestampMaxRangeCount, utils.TimestampMaxCount)
 
 	// Get one or more timestamps
 	count := 1
@@ -181,28 +206,377 @@ func (s *HTTPServer) GetTimestamp(c echo.Context) error {
 		if err != nil || count < 1 {
 			return c.String(http.StatusBadRequest, fmt.Sprintf("invalid n param, must be a number >= 1 if provided"))
 		}
+		if int64(count) > maxCount {
+			return c.String(http.StatusBadRequest, fmt.Sprintf("invalid n param, must be <= %d", maxCount))
+		}
+	}
+
+	format, err := negotiateFormat(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
 	}
 
-	payload, err := s.EpochHost.GetUniqueTimestamp(ctx, count)
+	priority, err := parsePriority(c)
+	if err != nil {
+		return err
+	}
+
+	// Causality token, every returned timestamp will be strictly greater than min
+	var minTS timestamp.Timestamp
+	if m := c.QueryParam("min"); m != "" {
+		minTS, err = parseTimestamp(eh, m)
+		if err != nil {
+			return c.String(http.StatusBadRequest, fmt.Sprintf("invalid min param: %s", err))
+		}
+	}
+
+	reserved, err := eh.GetUniqueTimestamp(ctx, raft.TimestampRequest{Count: count, Priority: priority, Min: minTS})
+	s.setQueueDepthHeaders(c, eh)
+	if eh.IssuesLocally() {
+		// Timestamps from different nodes are only ordered once they are further apart than this
+		c.Response().Header().Set("X-Uncertainty-Ns", strconv.FormatInt(int64(eh.GetIssuing().UncertaintyNs), 10))
+	}
+	if errors.Is(err, raft.ErrMinTooFarAhead) || errors.Is(err, raft.ErrCountExceedsLogical) || errors.Is(err, raft.ErrCountExceedsQuota) {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	if errors.Is(err, raft.ErrQuotaExceeded) {
+		c.Response().Header().Set("Retry-After", "1")
+		return c.String(http.StatusTooManyRequests, err.Error())
+	}
+	if isShed(err) {
+		c.Response().Header().Set("Retry-After", "1")
+		return c.String(http.StatusServiceUnavailable, err.Error())
+	}
 	if err != nil {
 		return fmt.Errorf("error in EpochHost.GetUniqueTimestamp: %w", err)
 	}
 
-	return c.Blob(http.StatusOK, "application/octet-stream", payload)
+	if rangeMode {
+		return writeRange(c, format, reserved)
+	}
+	return writeTimestamps(c, format, reserved, eh.Packing())
+}
+
+// GetReadTimestamp returns a non-unique timestamp at least as large as every issued timestamp, for snapshot reads
+func (s *HTTPServer) GetReadTimestamp(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second)
+	defer cancel()
+	eh, err := s.leaderShard(c)
+	if err != nil {
+		return err
+	}
+
+	format, err := negotiateFormat(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	priority, err := parsePriority(c)
+	if err != nil {
+		return err
+	}
+
+	ts, err := eh.GetReadTimestamp(ctx, priority)
+	s.setQueueDepthHeaders(c, eh)
+	if errors.Is(err, raft.ErrQuotaExceeded) {
+		c.Response().Header().Set("Retry-After", "1")
+		return c.String(http.StatusTooManyRequests, err.Error())
+	}
+	if isShed(err) {
+		c.Response().Header().Set("Retry-After", "1")
+		return c.String(http.StatusServiceUnavailable, err.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("error in EpochHost.GetReadTimestamp: %w", err)
+	}
+
+	return writeTimestamps(c, format, timestamp.Range{Epoch: ts.Epoch, StartIndex: ts.Index, Count: 1}, eh.Packing())
+}
+
+// GetSafeReadTimestamp returns a stale read timestamp from any node, with its staleness in the X-Staleness-Ms header
+func (s *HTTPServer) GetSafeReadTimestamp(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second)
+	defer cancel()
+	eh, err := s.shard(c)
+	if err != nil {
+		return err
+	}
+
+	format, err := negotiateFormat(c)
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	readIndex := false
+	if r := c.QueryParam("readIndex"); r != "" {
+		readIndex, err = strconv.ParseBool(r)
+		if err != nil {
+			return c.String(http.StatusBadRequest, "invalid readIndex param, must be a boolean if provided")
+		}
+	}
+
+	var maxStaleness time.Duration
+	if m := c.QueryParam("maxStalenessMs"); m != "" {
+		ms, err := strconv.ParseInt(m, 10, 64)
+		if err != nil || ms < 1 {
+			return c.String(http.StatusBadRequest, "invalid maxStalenessMs param, must be a number >= 1 if provided")
+		}
+		maxStaleness = time.Millisecond * time.Duration(ms)
+	}
+
+	ts, staleness, err := eh.GetSafeReadTimestamp(ctx, readIndex)
+	if errors.Is(err, raft.ErrNoAppliedEpoch) {
+		return c.String(http.StatusServiceUnavailable, err.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("error in EpochHost.GetSafeReadTimestamp: %w", err)
+	}
+
+	c.Response().Header().Set("X-Staleness-Ms", strconv.FormatInt(staleness.Milliseconds(), 10))
+	if maxStaleness > 0 && staleness > maxStaleness {
+		// Let the client try another node, or the leader
+		return c.String(http.StatusServiceUnavailable, fmt.Sprintf("read timestamp is stale by %s, more than maxStalenessMs", staleness))
+	}
+
+	return writeTimestamps(c, format, timestamp.Range{Epoch: ts.Epoch, StartIndex: ts.Index, Count: 1}, eh.Packing())
+}
+
+// CommitWait blocks until the committed epoch is past the timestamp ts, for Spanner-style commit wait
+func (s *HTTPServer) CommitWait(c echo.Context) error {
+	eh, err := s.leaderShard(c)
+	if err != nil {
+		return err
+	}
+
+	ts, err := parseTimestamp(eh, c.QueryParam("ts"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("invalid ts param: %s", err))
+	}
+
+	timeoutMS := utils.CommitWaitMaxMS
+	if t := c.QueryParam("timeoutMs"); t != "" {
+		timeoutMS, err = strconv.ParseInt(t, 10, 64)
+		if err != nil || timeoutMS < 1 || timeoutMS > utils.CommitWaitMaxMS {
+			return c.String(http.StatusBadRequest, fmt.Sprintf("invalid timeoutMs param, must be a number between 1 and %d if provided", utils.CommitWaitMaxMS))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Millisecond*time.Duration(timeoutMS))
+	defer cancel()
+	_, err = eh.CommitWait(ctx, ts)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return c.String(http.StatusGatewayTimeout, "timed out waiting for the committed epoch to pass ts")
+	}
+	if err != nil {
+		return fmt.Errorf("error in EpochHost.CommitWait: %w", err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Now returns TrueTime-style [earliest, latest] bounds on the current time, from any node
+func (s *HTTPServer) Now(c echo.Context) error {
+	eh, err := s.shard(c)
+	if err != nil {
+		return err
+	}
+
+	interval, err := eh.Now()
+	if errors.Is(err, raft.ErrNoAppliedEpoch) {
+		return c.String(http.StatusServiceUnavailable, err.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("error in EpochHost.Now: %w", err)
+	}
+
+	return c.JSON(http.StatusOK, interval)
+}
+
+// NextSequence returns the next n values of a named sequence, which are strictly increasing across the cluster
+func (s *HTTPServer) NextSequence(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second)
+	defer cancel()
+	meta, err := s.metaLeader()
+	if err != nil {
+		return err
+	}
+
+	count := uint64(1)
+	if n := c.QueryParam("n"); n != "" {
+		count, err = strconv.ParseUint(n, 10, 64)
+		if err != nil || count < 1 {
+			return c.String(http.StatusBadRequest, "invalid n param, must be a number >= 1 if provided")
+		}
+		if count > uint64(utils.TimestampMaxRangeCount) {
+			return c.String(http.StatusBadRequest, fmt.Sprintf("invalid n param, must be <= %d", utils.TimestampMaxRangeCount))
+		}
+	}
+
+	seq, err := meta.NextSequence(ctx, c.Param("name"), count)
+	if errors.Is(err, raft.ErrInvalidSequence) {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+	if err != nil {
+		return fmt.Errorf("error in MetaHost.NextSequence: %w", err)
+	}
+
+	return c.JSON(http.StatusOK, seq)
+}
+
+// metaLeader returns the meta raft group, or an error response if this node is not its leader
+func (s *HTTPServer) metaLeader() (*raft.MetaHost, error) {
+	meta := s.Oracle.Meta()
+	leader, available, err := meta.GetLeader()
+	if err != nil {
+		return nil, fmt.Errorf("error in MetaHost.GetLeader: %w", err)
+	}
+
+	if !available {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "raft leadership not ready")
+	}
+
+	if leader != utils.NodeID {
+		return nil, echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("node (%d) is not the meta leader (%d)", utils.NodeID, leader))
+	}
+
+	return meta, nil
+}
+
+// shard returns the shard of the namespace query param picked by the key or shard query params, defaulting to
+// the first shard of the default namespace
+func (s *HTTPServer) shard(c echo.Context) (*raft.EpochHost, error) {
+	var shard uint64
+	if sh := c.QueryParam("shard"); sh != "" {
+		var err error
+		shard, err = strconv.ParseUint(sh, 10, 64)
+		if err != nil {
+			return nil, echo.NewHTTPError(http.StatusBadRequest, "invalid shard param, must be a number if provided")
+		}
+	}
+
+	eh, err := s.Oracle.ShardFor(c.QueryParam("namespace"), shard, c.QueryParam("key"))
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return eh, nil
+}
+
+// leaderShard returns the requested shard, or an error response if this node is not its raft leader,
+// unless every node issues timestamps
+func (s *HTTPServer) leaderShard(c echo.Context) (*raft.EpochHost, error) {
+	eh, err := s.shard(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if eh.IssuesLocally() {
+		return eh, nil
+	}
+
+	leader, available, err := eh.GetLeader()
+	if err != nil {
+		return nil, fmt.Errorf("error in NodeHost.GetLeaderID: %w", err)
+	}
+
+	if !available {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "raft leadership not ready")
+	}
+
+	if leader != utils.NodeID {
+		return nil, echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("node (%d) is not the leader (%d)", utils.NodeID, leader))
+	}
+
+	return eh, nil
+}
+
+// parsePriority reads the priority hint, the query param takes precedence over the header
+func parsePriority(c echo.Context) (raft.Priority, error) {
+	priorityHint := c.QueryParam("priority")
+	if priorityHint == "" {
+		priorityHint = c.Request().Header.Get("X-Priority")
+	}
+	priority, err := raft.ParsePriority(priorityHint)
+	if err != nil {
+		return priority, echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return priority, nil
+}
+
+// isShed is whether the request was shed by admission control, and the client should retry later
+func isShed(err error) bool {
+	return errors.Is(err, raft.ErrOverloaded) || errors.Is(err, raft.ErrQueueBudgetExceeded)
+}
+
+// setQueueDepthHeaders lets clients see how saturated the request buffer is, so they can back off before requests are shed
+func (s *HTTPServer) setQueueDepthHeaders(c echo.Context, eh *raft.EpochHost) {
+	depth, capacity := eh.QueueDepth()
+	c.Response().Header().Set("X-Queue-Depth", strconv.Itoa(depth))
+	c.Response().Header().Set("X-Queue-Capacity", strconv.Itoa(capacity))
 }
 
 func (s *HTTPServer) GetMembership(c echo.Context) error {
 	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second)
 	defer cancel()
 
-	membership, err := s.EpochHost.GetMembership(ctx)
+	membership, err := s.Oracle.GetMembership(ctx, c.QueryParam("namespace"))
+	if errors.Is(err, raft.ErrInvalidNamespace) {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
 	if err != nil {
-		return fmt.Errorf("error in EpochHost.GetMembership: %w", err)
+		return fmt.Errorf("error in Oracle.GetMembership: %w", err)
 	}
 
 	return c.JSON(http.StatusOK, membership)
 }
 
+func (s *HTTPServer) GetBatching(c echo.Context) error {
+	return c.JSON(http.StatusOK, s.Oracle.GetBatching())
+}
+
+// SetBatching updates the linger window and max batch size of the reader agent on this node
+func (s *HTTPServer) SetBatching(c echo.Context) error {
+	var reqBody raft.BatchingConfig
+	if err := ValidateRequest(c, &reqBody); err != nil {
+		return err
+	}
+
+	s.Oracle.SetBatching(reqBody)
+
+	return c.JSON(http.StatusOK, s.Oracle.GetBatching())
+}
+
+func (s *HTTPServer) GetIssuing(c echo.Context) error {
+	eh, err := s.shard(c)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, eh.GetIssuing())
+}
+
+// SetIssuing replicates a new drift interval of a namespace for the bounded issue mode to every node,
+// the mode itself can't be changed at runtime
+func (s *HTTPServer) SetIssuing(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second)
+	defer cancel()
+	eh, err := s.shard(c)
+	if err != nil {
+		return err
+	}
+
+	var reqBody raft.IssuingConfig
+	if err := ValidateRequest(c, &reqBody); err != nil {
+		return err
+	}
+
+	err = eh.SetDriftInterval(ctx, reqBody.DriftIntervalMicros)
+	if err != nil {
+		return fmt.Errorf("error in EpochHost.SetDriftInterval: %w", err)
+	}
+
+	return c.JSON(http.StatusOK, eh.GetIssuing())
+}
+
 func (s *HTTPServer) Shutdown(ctx context.Context) error {
 	err := s.quicServer.Close()
 	if err != nil {
@@ -243,69 +617,3 @@ func LoggerMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 		return nil
 	}
 }
-
-const (
-	certFile = "cert.pem"
-	keyFile  = "key.pem"
-)
-
-func generateTLSCert() (tls.Certificate, error) {
-	// Check if certificate and key files exist
-	if fileExists(certFile) && fileExists(keyFile) {
-		// Load existing certificate and key
-		return tls.LoadX509KeyPair(certFile, keyFile)
-	}
-
-	// Generate a new certificate and key
-	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
-	if err != nil {
-		return tls.Certificate{}, err
-	}
-
-	template := x509.Certificate{
-		SerialNumber: big.NewInt(1),
-		Subject: pkix.Name{
-			Organization: []string{"Example Co"},
-		},
-		NotBefore: time.Now(),
-		NotAfter:  time.Now().Add(time.Hour * 24 * 180), // Valid for 180 days
-		KeyUsage:  x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
-		ExtKeyUsage: []x509.ExtKeyUsage{
-			x509.ExtKeyUsageServerAuth,
-		},
-		BasicConstraintsValid: true,
-	}
-
-	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
-	if err != nil {
-		return tls.Certificate{}, err
-	}
-
-	// Save the certificate
-	certOut, err := os.Create(certFile)
-	if err != nil {
-		return tls.Certificate{}, err
-	}
-	pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: certDER})
-	certOut.Close()
-
-	// Save the key
-	keyOut, err := os.Create(keyFile)
-	if err != nil {
-		return tls.Certificate{}, err
-	}
-	keyBytes, err := x509.MarshalECPrivateKey(privateKey)
-	if err != nil {
-		return tls.Certificate{}, err
-	}
-	pem.Encode(keyOut, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
-	keyOut.Close()
-
-	// Load the newly created certificate and key
-	return tls.LoadX509KeyPair(certFile, keyFile)
-}
-
-func fileExists(filename string) bool {
-	_, err := os.Stat(filename)
-	return !os.IsNotExist(err)
-}
@@ -0,0 +1,63 @@
+package http_server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/id"
+	"github.com/danthegoodman1/EpicEpoch/raft"
+	"github.com/labstack/echo/v4"
+)
+
+// GetIDs returns n IDs of the format derived from unique timestamps, newline separated or as a JSON array
+func (s *HTTPServer) GetIDs(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second)
+	defer cancel()
+	eh, err := s.leaderShard(c)
+	if err != nil {
+		return err
+	}
+
+	format, err := id.ParseFormat(c.QueryParam("format"))
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	count := 1
+	if n := c.QueryParam("n"); n != "" {
+		count, err = strconv.Atoi(n)
+		if err != nil || count < 1 {
+			return c.String(http.StatusBadRequest, "invalid n param, must be a number >= 1 if provided")
+		}
+	}
+
+	priority, err := parsePriority(c)
+	if err != nil {
+		return err
+	}
+
+	ids, err := eh.GetIDs(ctx, format, count, priority)
+	s.setQueueDepthHeaders(c, eh)
+	switch {
+	case errors.Is(err, raft.ErrInvalidIDCount), errors.Is(err, raft.ErrIDNamespace), errors.Is(err, raft.ErrCountExceedsLogical), errors.Is(err, raft.ErrCountExceedsQuota):
+		return c.String(http.StatusBadRequest, err.Error())
+	case errors.Is(err, raft.ErrQuotaExceeded):
+		c.Response().Header().Set("Retry-After", "1")
+		return c.String(http.StatusTooManyRequests, err.Error())
+	case isShed(err), errors.Is(err, id.ErrDoesNotFit):
+		c.Response().Header().Set("Retry-After", "1")
+		return c.String(http.StatusServiceUnavailable, err.Error())
+	case err != nil:
+		return fmt.Errorf("error in EpochHost.GetIDs: %w", err)
+	}
+
+	if strings.Contains(c.Request().Header.Get(echo.HeaderAccept), echo.MIMEApplicationJSON) {
+		return c.JSON(http.StatusOK, ids)
+	}
+	return c.String(http.StatusOK, strings.Join(ids, "\n")+"\n")
+}
@@ -0,0 +1,174 @@
+package http_server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/raft"
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	LockRequest struct {
+		Owner string `json:"owner" query:"owner" validate:"required"`
+		// Token is the fencing token of the lease to renew or release, in any of the text formats
+		Token string `json:"token" query:"token"`
+		TTLMs int64  `json:"ttlMs" query:"ttlMs"`
+	}
+
+	lockResponse struct {
+		raft.Lock
+		// TokenHex is the fencing token as hex, to pass back when renewing or releasing
+		TokenHex string `json:"tokenHex"`
+	}
+)
+
+func newLockResponse(lock raft.Lock) lockResponse {
+	return lockResponse{Lock: lock, TokenHex: lock.Token.Hex()}
+}
+
+// AcquireLock acquires a lease of the lock for ttlMs, returning its fencing token
+func (s *HTTPServer) AcquireLock(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second)
+	defer cancel()
+	if err := s.issuerLeader(); err != nil {
+		return err
+	}
+
+	var reqBody LockRequest
+	if err := ValidateRequest(c, &reqBody); err != nil {
+		return err
+	}
+	ttl, err := lockTTL(reqBody.TTLMs)
+	if err != nil {
+		return err
+	}
+
+	lock, err := s.Oracle.AcquireLock(ctx, c.Param("name"), reqBody.Owner, ttl)
+	if errors.Is(err, raft.ErrLockHeld) {
+		return c.JSON(http.StatusLocked, newLockResponse(lock))
+	}
+	if err != nil {
+		return lockError(c, err, "AcquireLock")
+	}
+
+	return c.JSON(http.StatusOK, newLockResponse(lock))
+}
+
+// RenewLock extends a held lease to ttlMs from now
+func (s *HTTPServer) RenewLock(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second)
+	defer cancel()
+	if err := s.issuerLeader(); err != nil {
+		return err
+	}
+
+	var reqBody LockRequest
+	if err := ValidateRequest(c, &reqBody); err != nil {
+		return err
+	}
+	ttl, err := lockTTL(reqBody.TTLMs)
+	if err != nil {
+		return err
+	}
+	token, err := timestamp.Parse(reqBody.Token)
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("invalid token: %s", err))
+	}
+
+	lock, err := s.Oracle.RenewLock(ctx, c.Param("name"), reqBody.Owner, token, ttl)
+	if err != nil {
+		return lockError(c, err, "RenewLock")
+	}
+
+	return c.JSON(http.StatusOK, newLockResponse(lock))
+}
+
+// ReleaseLock releases a held lease
+func (s *HTTPServer) ReleaseLock(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second)
+	defer cancel()
+	if err := s.issuerLeader(); err != nil {
+		return err
+	}
+
+	var reqBody LockRequest
+	if err := ValidateRequest(c, &reqBody); err != nil {
+		return err
+	}
+	token, err := timestamp.Parse(reqBody.Token)
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("invalid token: %s", err))
+	}
+
+	err = s.Oracle.ReleaseLock(ctx, c.Param("name"), reqBody.Owner, token)
+	if err != nil {
+		return lockError(c, err, "ReleaseLock")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetLock returns the latest lease of a lock, from any node
+func (s *HTTPServer) GetLock(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second)
+	defer cancel()
+
+	lock, err := s.Oracle.GetLock(ctx, c.Param("name"))
+	if err != nil {
+		return fmt.Errorf("error in Oracle.GetLock: %w", err)
+	}
+	if lock.Owner == "" {
+		return c.String(http.StatusNotFound, "lock has never been acquired")
+	}
+
+	return c.JSON(http.StatusOK, newLockResponse(lock))
+}
+
+// issuerLeader returns an error response if this node is not the leader of the shard that drives lease expiries.
+// This is also required in the bounded issue mode, so that lease expiries are judged by a single clock.
+func (s *HTTPServer) issuerLeader() error {
+	eh := s.Oracle.Issuer()
+	leader, available, err := eh.GetLeader()
+	if err != nil {
+		return fmt.Errorf("error in NodeHost.GetLeaderID: %w", err)
+	}
+
+	if !available {
+		return echo.NewHTTPError(http.StatusInternalServerError, "raft leadership not ready")
+	}
+
+	if leader != utils.NodeID {
+		return echo.NewHTTPError(http.StatusConflict, fmt.Sprintf("node (%d) is not the leader (%d)", utils.NodeID, leader))
+	}
+
+	return nil
+}
+
+func lockTTL(ttlMs int64) (time.Duration, error) {
+	if ttlMs < 1 || ttlMs > utils.LockMaxTTLMS {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("invalid ttlMs, must be between 1 and %d", utils.LockMaxTTLMS))
+	}
+	return time.Millisecond * time.Duration(ttlMs), nil
+}
+
+func lockError(c echo.Context, err error, method string) error {
+	switch {
+	case errors.Is(err, raft.ErrInvalidLock):
+		return c.String(http.StatusBadRequest, err.Error())
+	case errors.Is(err, raft.ErrLockLost):
+		return c.String(http.StatusPreconditionFailed, err.Error())
+	case errors.Is(err, raft.ErrQuotaExceeded):
+		c.Response().Header().Set("Retry-After", "1")
+		return c.String(http.StatusTooManyRequests, err.Error())
+	case isShed(err):
+		c.Response().Header().Set("Retry-After", "1")
+		return c.String(http.StatusServiceUnavailable, err.Error())
+	}
+	return fmt.Errorf("error in Oracle.%s: %w", method, err)
+}
@@ -0,0 +1,106 @@
+package http_server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/raft"
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	SafepointRequest struct {
+		// Timestamp is the min active read timestamp of the service, in any of the text formats
+		Timestamp string `json:"timestamp" validate:"required"`
+		TTLMs     int64  `json:"ttlMs"`
+	}
+
+	safepointResponse struct {
+		raft.Safepoint
+		// SafepointHex is the safepoint as hex
+		SafepointHex string `json:"safepointHex"`
+	}
+)
+
+func newSafepointResponse(sp raft.Safepoint) safepointResponse {
+	return safepointResponse{Safepoint: sp, SafepointHex: sp.Safepoint.Hex()}
+}
+
+// GetSafepoint returns the GC safepoint and the live registrations, from any node
+func (s *HTTPServer) GetSafepoint(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second)
+	defer cancel()
+
+	sp, err := s.Oracle.GetSafepoint(ctx)
+	if err != nil {
+		return fmt.Errorf("error in Oracle.GetSafepoint: %w", err)
+	}
+
+	return c.JSON(http.StatusOK, newSafepointResponse(sp))
+}
+
+// RegisterSafepoint registers the min active read timestamp of a service for ttlMs
+func (s *HTTPServer) RegisterSafepoint(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second)
+	defer cancel()
+	if err := s.issuerLeader(); err != nil {
+		return err
+	}
+
+	var reqBody SafepointRequest
+	if err := ValidateRequest(c, &reqBody); err != nil {
+		return err
+	}
+	if reqBody.TTLMs < 1 || reqBody.TTLMs > utils.SafepointMaxTTLMS {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("invalid ttlMs, must be between 1 and %d", utils.SafepointMaxTTLMS))
+	}
+	ts, err := timestamp.Parse(reqBody.Timestamp)
+	if err != nil {
+		return c.String(http.StatusBadRequest, fmt.Sprintf("invalid timestamp: %s", err))
+	}
+
+	sp, err := s.Oracle.RegisterSafepoint(ctx, c.Param("service"), ts, time.Millisecond*time.Duration(reqBody.TTLMs))
+	if errors.Is(err, raft.ErrBelowSafepoint) {
+		return c.JSON(http.StatusPreconditionFailed, newSafepointResponse(sp))
+	}
+	if err != nil {
+		return safepointError(c, err, "RegisterSafepoint")
+	}
+
+	return c.JSON(http.StatusOK, newSafepointResponse(sp))
+}
+
+// RemoveSafepoint removes the registration of a service
+func (s *HTTPServer) RemoveSafepoint(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), time.Second)
+	defer cancel()
+	if err := s.issuerLeader(); err != nil {
+		return err
+	}
+
+	sp, err := s.Oracle.RemoveSafepoint(ctx, c.Param("service"))
+	if err != nil {
+		return safepointError(c, err, "RemoveSafepoint")
+	}
+
+	return c.JSON(http.StatusOK, newSafepointResponse(sp))
+}
+
+func safepointError(c echo.Context, err error, method string) error {
+	switch {
+	case errors.Is(err, raft.ErrInvalidService):
+		return c.String(http.StatusBadRequest, err.Error())
+	case errors.Is(err, raft.ErrQuotaExceeded):
+		c.Response().Header().Set("Retry-After", "1")
+		return c.String(http.StatusTooManyRequests, err.Error())
+	case isShed(err):
+		c.Response().Header().Set("Retry-After", "1")
+		return c.String(http.StatusServiceUnavailable, err.Error())
+	}
+	return fmt.Errorf("error in Oracle.%s: %w", method, err)
+}
@@ -0,0 +1,211 @@
+// Package id derives globally ordered IDs from hybrid timestamps.
+//
+// Every ID format keeps the order of the timestamps it was derived from, and two different timestamps
+// never derive the same ID as long as their index fits (see ErrDoesNotFit). Snowflakes only keep the
+// millisecond of the epoch, so they are only unique if each millisecond is only used by one epoch.
+// The index is packed below the time, and its high PrefixBits (the shard or node, if any) are kept in
+// the high bits of the packed index so that IDs stay unique across shards and nodes.
+package id
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/google/uuid"
+)
+
+type Format string
+
+const (
+	FormatUUIDv7    Format = "uuidv7"
+	FormatULID      Format = "ulid"
+	FormatSnowflake Format = "snowflake"
+
+	// uuidv7IndexBits is what is left of the 122 non-version bits after the millisecond and sub-millisecond time
+	uuidv7IndexBits = 122 - 48 - subMillisBits
+	// ulidIndexBits is what is left of the 80 random bits after the sub-millisecond time
+	ulidIndexBits = 80 - subMillisBits
+	// subMillisBits holds the nanoseconds within a millisecond
+	subMillisBits = 20
+)
+
+var (
+	ErrInvalidFormat = errors.New("id format must be one of uuidv7, ulid, or snowflake")
+	// ErrDoesNotFit is returned when the index of a timestamp has more bits than the format has room for,
+	// or (for snowflakes) it shares a millisecond with another epoch. A timestamp in a later epoch will fit.
+	ErrDoesNotFit = errors.New("timestamp does not fit in the id format")
+	// ErrTimeOverflow is returned when the time of a snowflake does not fit in its time bits
+	ErrTimeOverflow = errors.New("time overflows the snowflake time bits")
+)
+
+type (
+	// SnowflakeLayout is the bit layout of a 64 bit snowflake: the sign bit is always 0, followed by TimeBits of
+	// milliseconds since EpochMs, then IndexBits of the timestamp index.
+	SnowflakeLayout struct {
+		// EpochMs is the custom epoch in unix milliseconds
+		EpochMs   uint64
+		TimeBits  int
+		IndexBits int
+	}
+
+	// Encoder derives IDs of a format from timestamps
+	Encoder struct {
+		Format Format
+		// PrefixBits is how many high bits of the index are the shard or node
+		PrefixBits int
+		Snowflake  SnowflakeLayout
+	}
+)
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case "":
+		return FormatUUIDv7, nil
+	case FormatUUIDv7, FormatULID, FormatSnowflake:
+		return f, nil
+	}
+	return "", ErrInvalidFormat
+}
+
+func (l SnowflakeLayout) Validate() error {
+	if l.TimeBits < 1 || l.IndexBits < 1 || l.TimeBits+l.IndexBits > 63 {
+		return fmt.Errorf("snowflake time bits (%d) and index bits (%d) must each be >= 1, and add up to <= 63", l.TimeBits, l.IndexBits)
+	}
+	return nil
+}
+
+// Millis is the millisecond an epoch is in, which snowflakes keep instead of the full epoch
+func Millis(epoch uint64) uint64 {
+	return epoch / 1e6
+}
+
+// indexBits is how many bits the format has for the index
+func (e Encoder) indexBits() int {
+	switch e.Format {
+	case FormatUUIDv7:
+		return uuidv7IndexBits
+	case FormatULID:
+		return ulidIndexBits
+	case FormatSnowflake:
+		return e.Snowflake.IndexBits
+	}
+	return 0
+}
+
+// MaxCount is the most IDs that can be derived from a single epoch, as indexes start at 1 every epoch
+func (e Encoder) MaxCount() uint64 {
+	counterBits := e.indexBits() - e.PrefixBits
+	switch {
+	case counterBits <= 0:
+		return 0
+	case counterBits >= 64:
+		return math.MaxUint64
+	}
+	return 1<<counterBits - 1
+}
+
+// packIndex packs an index into bits, keeping the high prefixBits (the shard or node) at the top
+func packIndex(index uint64, prefixBits, bits int) (uint64, error) {
+	if prefixBits >= bits {
+		return 0, ErrDoesNotFit
+	}
+	counterBits := 64 - prefixBits
+	counter := index
+	if counterBits < 64 {
+		counter = index & (1<<counterBits - 1)
+	}
+	packedCounterBits := bits - prefixBits
+	if packedCounterBits < 64 && counter >= 1<<packedCounterBits {
+		return 0, ErrDoesNotFit
+	}
+	return index>>counterBits<<packedCounterBits | counter, nil
+}
+
+// Encode derives the ID of a timestamp
+func (e Encoder) Encode(ts timestamp.Timestamp) (string, error) {
+	switch e.Format {
+	case FormatUUIDv7:
+		u, err := e.UUIDv7(ts)
+		if err != nil {
+			return "", err
+		}
+		return u.String(), nil
+	case FormatULID:
+		hi, lo, err := e.ULID(ts)
+		if err != nil {
+			return "", err
+		}
+		// A ULID is the same 26 character Crockford base32 encoding of 128 bits
+		return timestamp.Timestamp{Epoch: hi, Index: lo}.Base32(), nil
+	case FormatSnowflake:
+		sf, err := e.SnowflakeID(ts)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatUint(sf, 10), nil
+	}
+	return "", ErrInvalidFormat
+}
+
+// EncodeRange derives the IDs of every timestamp in a range
+func (e Encoder) EncodeRange(r timestamp.Range) ([]string, error) {
+	ids := make([]string, 0, r.Count)
+	it := r.Iter()
+	for ts, ok := it.Next(); ok; ts, ok = it.Next() {
+		id, err := e.Encode(ts)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// UUIDv7 packs the millisecond into the 48 bit unix_ts_ms, and the sub-millisecond nanoseconds followed by the
+// index into the remaining 74 bits (RFC 9562 allows replacing the random bits with more precise time and a counter)
+func (e Encoder) UUIDv7(ts timestamp.Timestamp) (uuid.UUID, error) {
+	index, err := packIndex(ts.Index, e.PrefixBits, uuidv7IndexBits)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	subMillis := ts.Epoch % 1e6
+
+	var u uuid.UUID
+	binary.BigEndian.PutUint64(u[0:8], Millis(ts.Epoch)<<16|0x7<<12|subMillis>>8)
+	binary.BigEndian.PutUint64(u[8:16], 0b10<<62|(subMillis&0xff)<<uuidv7IndexBits|index)
+	return u, nil
+}
+
+// ULID packs the millisecond into the 48 bit time, and the sub-millisecond nanoseconds followed by
+// the index into the 80 random bits. It returns the high and low 64 bits.
+func (e Encoder) ULID(ts timestamp.Timestamp) (uint64, uint64, error) {
+	index, err := packIndex(ts.Index, e.PrefixBits, ulidIndexBits)
+	if err != nil {
+		return 0, 0, err
+	}
+	subMillis := ts.Epoch % 1e6
+
+	// The 60 bit index spans the top 4 bits of the low half
+	hi := Millis(ts.Epoch)<<16 | subMillis>>4
+	lo := (subMillis&0xf)<<ulidIndexBits | index
+	return hi, lo, nil
+}
+
+// SnowflakeID packs the milliseconds since the custom epoch followed by the index. It drops the sub-millisecond
+// nanoseconds, so the caller must make sure no two epochs that IDs are derived from share a millisecond.
+func (e Encoder) SnowflakeID(ts timestamp.Timestamp) (uint64, error) {
+	l := e.Snowflake
+	millis := Millis(ts.Epoch)
+	if millis < l.EpochMs || millis-l.EpochMs >= 1<<l.TimeBits {
+		return 0, ErrTimeOverflow
+	}
+	index, err := packIndex(ts.Index, e.PrefixBits, l.IndexBits)
+	if err != nil {
+		return 0, err
+	}
+	return (millis-l.EpochMs)<<l.IndexBits | index, nil
+}
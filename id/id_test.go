@@ -0,0 +1,123 @@
+package id
+
+import (
+	"encoding/binary"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// orderedTimestamps are strictly increasing, across sub-millisecond epochs, milliseconds, and shards
+var orderedTimestamps = []timestamp.Timestamp{
+	{Epoch: 1720000000000000000, Index: 1},
+	{Epoch: 1720000000000000000, Index: 2},
+	{Epoch: 1720000000000000000, Index: 1<<56 | 1},
+	{Epoch: 1720000000000000001, Index: 1},
+	{Epoch: 1720000000000999999, Index: 7},
+	{Epoch: 1720000000001000000, Index: 1},
+	{Epoch: 1720000000001000000, Index: 3<<56 | 1},
+}
+
+func TestEncodeKeepsOrder(t *testing.T) {
+	for _, format := range []Format{FormatUUIDv7, FormatULID} {
+		e := Encoder{Format: format, PrefixBits: 8}
+		var ids []string
+		for _, ts := range orderedTimestamps {
+			id, err := e.Encode(ts)
+			if !assert.Nil(t, err) {
+				return
+			}
+			ids = append(ids, id)
+		}
+		assert.True(t, sort.StringsAreSorted(ids), format)
+		assert.Len(t, uniq(ids), len(ids), format)
+	}
+}
+
+func TestUUIDv7Fields(t *testing.T) {
+	e := Encoder{Format: FormatUUIDv7}
+	u, err := e.UUIDv7(timestamp.Timestamp{Epoch: 1720000000123456789, Index: 5})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, uuid.Version(7), u.Version())
+	assert.Equal(t, uuid.RFC4122, u.Variant())
+	assert.Equal(t, uint64(1720000000123), binary.BigEndian.Uint64(u[0:8])>>16)
+	parsed, err := uuid.Parse(u.String())
+	assert.Nil(t, err)
+	assert.Equal(t, u, parsed)
+}
+
+func TestULIDFormat(t *testing.T) {
+	e := Encoder{Format: FormatULID}
+	id, err := e.Encode(timestamp.Timestamp{Epoch: 1720000000123456789, Index: 5})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Len(t, id, 26)
+	// The first 10 characters are the millisecond
+	decoded, err := timestamp.ParseBase32(id)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1720000000123), decoded.Epoch>>16)
+}
+
+func TestSnowflake(t *testing.T) {
+	e := Encoder{Format: FormatSnowflake, PrefixBits: 8, Snowflake: SnowflakeLayout{EpochMs: 1704067200000, TimeBits: 41, IndexBits: 22}}
+	assert.Nil(t, e.Snowflake.Validate())
+
+	sf, err := e.SnowflakeID(timestamp.Timestamp{Epoch: 1704067200005000000, Index: 3<<56 | 9})
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, uint64(5)<<22|3<<14|9, sf)
+
+	// Different shards stay unique within a millisecond, and later milliseconds are larger
+	var prev uint64
+	for _, ts := range orderedTimestamps[3:] {
+		ts.Epoch -= 1720000000000000000 - 1704067200000000000
+		sf, err := e.Encode(ts)
+		if !assert.Nil(t, err) {
+			return
+		}
+		n, _ := strconv.ParseUint(sf, 10, 64)
+		assert.Greater(t, n, prev)
+		prev = n
+	}
+
+	_, err = e.SnowflakeID(timestamp.Timestamp{Epoch: 1600000000000000000, Index: 1})
+	assert.ErrorIs(t, err, ErrTimeOverflow)
+	assert.NotNil(t, SnowflakeLayout{TimeBits: 41, IndexBits: 23}.Validate())
+}
+
+func TestDoesNotFit(t *testing.T) {
+	e := Encoder{Format: FormatSnowflake, PrefixBits: 8, Snowflake: SnowflakeLayout{EpochMs: 1704067200000, TimeBits: 41, IndexBits: 22}}
+	assert.Equal(t, uint64(1<<14-1), e.MaxCount())
+
+	_, err := e.SnowflakeID(timestamp.Timestamp{Epoch: 1704067200005000000, Index: 1 << 14})
+	assert.ErrorIs(t, err, ErrDoesNotFit)
+
+	e = Encoder{Format: FormatULID}
+	assert.Equal(t, uint64(1<<60-1), e.MaxCount())
+	_, _, err = e.ULID(timestamp.Timestamp{Epoch: 1720000000000000000, Index: 1 << 60})
+	assert.ErrorIs(t, err, ErrDoesNotFit)
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("")
+	assert.Nil(t, err)
+	assert.Equal(t, FormatUUIDv7, f)
+	_, err = ParseFormat("uuidv4")
+	assert.ErrorIs(t, err, ErrInvalidFormat)
+}
+
+func uniq(ids []string) map[string]struct{} {
+	m := map[string]struct{}{}
+	for _, id := range ids {
+		m[id] = struct{}{}
+	}
+	return m
+}
@@ -2,9 +2,17 @@ package main
 
 import (
 	"context"
+	"errors"
 	"github.com/danthegoodman1/EpicEpoch/gologger"
+	"github.com/danthegoodman1/EpicEpoch/grpc_server"
 	"github.com/danthegoodman1/EpicEpoch/http_server"
+	"github.com/danthegoodman1/EpicEpoch/observability"
+	"github.com/danthegoodman1/EpicEpoch/quic_server"
 	"github.com/danthegoodman1/EpicEpoch/raft"
+	"github.com/danthegoodman1/EpicEpoch/resp_server"
+	"github.com/danthegoodman1/EpicEpoch/tcp_server"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -23,16 +31,20 @@ func main() {
 		os.Exit(1)
 	}
 
-	// prometheusReporter := observability.NewPrometheusReporter()
-	// go func() {
-	// 	err := observability.StartInternalHTTPServer(":8042", prometheusReporter)
-	// 	if err != nil && !errors.Is(err, http.ErrServerClosed) {
-	// 		logger.Error().Err(err).Msg("api server couldn't start")
-	// 		os.Exit(1)
-	// 	}
-	// }()
+	prometheusReporter := observability.NewPrometheusReporter()
+	go func() {
+		err := observability.StartInternalHTTPServer(utils.GetEnvOrDefault("INTERNAL_HTTP_ADDR", ":8042"), prometheusReporter)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error().Err(err).Msg("internal api server couldn't start")
+			os.Exit(1)
+		}
+	}()
 
 	httpServer := http_server.StartHTTPServer(nodeHost)
+	grpcServer := grpc_server.StartGRPCServer(nodeHost)
+	respServer := resp_server.StartRESPServer(nodeHost)
+	tcpServer := tcp_server.StartTCPServer(nodeHost)
+	quicServer := quic_server.StartQUICServer(nodeHost)
 
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
@@ -46,6 +58,32 @@ func main() {
 	} else {
 		logger.Info().Msg("successfully shutdown HTTP server")
 	}
+	if err := grpcServer.Shutdown(ctx); err != nil {
+		logger.Error().Err(err).Msg("failed to shutdown gRPC server")
+	} else {
+		logger.Info().Msg("successfully shutdown gRPC server")
+	}
+	if respServer != nil {
+		if err := respServer.Shutdown(ctx); err != nil {
+			logger.Error().Err(err).Msg("failed to shutdown RESP server")
+		} else {
+			logger.Info().Msg("successfully shutdown RESP server")
+		}
+	}
+	if tcpServer != nil {
+		if err := tcpServer.Shutdown(ctx); err != nil {
+			logger.Error().Err(err).Msg("failed to shutdown TCP server")
+		} else {
+			logger.Info().Msg("successfully shutdown TCP server")
+		}
+	}
+	if quicServer != nil {
+		if err := quicServer.Shutdown(ctx); err != nil {
+			logger.Error().Err(err).Msg("failed to shutdown QUIC server")
+		} else {
+			logger.Info().Msg("successfully shutdown QUIC server")
+		}
+	}
 
 	nodeHost.Stop()
 }
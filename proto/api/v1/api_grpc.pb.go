@@ -0,0 +1,612 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: api/v1/api.proto
+
+package apiv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	HybridTimestampAPI_GetTimestamp_FullMethodName         = "/api.v1.HybridTimestampAPI/GetTimestamp"
+	HybridTimestampAPI_GetTimestampRange_FullMethodName    = "/api.v1.HybridTimestampAPI/GetTimestampRange"
+	HybridTimestampAPI_GetReadTimestamp_FullMethodName     = "/api.v1.HybridTimestampAPI/GetReadTimestamp"
+	HybridTimestampAPI_GetSafeReadTimestamp_FullMethodName = "/api.v1.HybridTimestampAPI/GetSafeReadTimestamp"
+	HybridTimestampAPI_CommitWait_FullMethodName           = "/api.v1.HybridTimestampAPI/CommitWait"
+	HybridTimestampAPI_Now_FullMethodName                  = "/api.v1.HybridTimestampAPI/Now"
+	HybridTimestampAPI_NextSequence_FullMethodName         = "/api.v1.HybridTimestampAPI/NextSequence"
+	HybridTimestampAPI_AcquireLock_FullMethodName          = "/api.v1.HybridTimestampAPI/AcquireLock"
+	HybridTimestampAPI_RenewLock_FullMethodName            = "/api.v1.HybridTimestampAPI/RenewLock"
+	HybridTimestampAPI_ReleaseLock_FullMethodName          = "/api.v1.HybridTimestampAPI/ReleaseLock"
+	HybridTimestampAPI_RegisterSafepoint_FullMethodName    = "/api.v1.HybridTimestampAPI/RegisterSafepoint"
+	HybridTimestampAPI_RemoveSafepoint_FullMethodName      = "/api.v1.HybridTimestampAPI/RemoveSafepoint"
+	HybridTimestampAPI_GetSafepoint_FullMethodName         = "/api.v1.HybridTimestampAPI/GetSafepoint"
+	HybridTimestampAPI_GetIDs_FullMethodName               = "/api.v1.HybridTimestampAPI/GetIDs"
+)
+
+// HybridTimestampAPIClient is the client API for HybridTimestampAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type HybridTimestampAPIClient interface {
+	GetTimestamp(ctx context.Context, in *GetTimestampRequest, opts ...grpc.CallOption) (*HybridTimestamp, error)
+	// GetTimestampRange reserves count timestamps without sending each of them, for bulk loading
+	GetTimestampRange(ctx context.Context, in *GetTimestampRequest, opts ...grpc.CallOption) (*TimestampRange, error)
+	// GetReadTimestamp returns a non-unique timestamp at least as large as every issued timestamp, for snapshot reads
+	GetReadTimestamp(ctx context.Context, in *GetReadTimestampRequest, opts ...grpc.CallOption) (*HybridTimestamp, error)
+	// GetSafeReadTimestamp can be served by any node, including followers, for stale reads
+	GetSafeReadTimestamp(ctx context.Context, in *GetSafeReadTimestampRequest, opts ...grpc.CallOption) (*SafeReadTimestamp, error)
+	// CommitWait returns once the committed epoch is past the timestamp, so it can never be served again
+	CommitWait(ctx context.Context, in *CommitWaitRequest, opts ...grpc.CallOption) (*CommitWaitResponse, error)
+	// Now returns TrueTime-style bounds on the current time, and can be served by any node
+	Now(ctx context.Context, in *NowRequest, opts ...grpc.CallOption) (*TimeInterval, error)
+	// NextSequence returns the next values of a named sequence, which are strictly increasing but may have gaps.
+	// It must be sent to the leader of the meta raft group.
+	NextSequence(ctx context.Context, in *NextSequenceRequest, opts ...grpc.CallOption) (*SequenceRange, error)
+	// AcquireLock acquires a lease of a named lock with a fencing token, it must be sent to the leader
+	AcquireLock(ctx context.Context, in *AcquireLockRequest, opts ...grpc.CallOption) (*LockLease, error)
+	RenewLock(ctx context.Context, in *RenewLockRequest, opts ...grpc.CallOption) (*LockLease, error)
+	ReleaseLock(ctx context.Context, in *ReleaseLockRequest, opts ...grpc.CallOption) (*Empty, error)
+	// RegisterSafepoint registers the min active read timestamp of a service, it must be sent to the leader
+	RegisterSafepoint(ctx context.Context, in *RegisterSafepointRequest, opts ...grpc.CallOption) (*Safepoint, error)
+	RemoveSafepoint(ctx context.Context, in *RemoveSafepointRequest, opts ...grpc.CallOption) (*Safepoint, error)
+	// GetSafepoint can be served by any node
+	GetSafepoint(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Safepoint, error)
+	// GetIDs derives ordered IDs from unique timestamps
+	GetIDs(ctx context.Context, in *GetIDsRequest, opts ...grpc.CallOption) (*IDs, error)
+}
+
+type hybridTimestampAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewHybridTimestampAPIClient(cc grpc.ClientConnInterface) HybridTimestampAPIClient {
+	return &hybridTimestampAPIClient{cc}
+}
+
+func (c *hybridTimestampAPIClient) GetTimestamp(ctx context.Context, in *GetTimestampRequest, opts ...grpc.CallOption) (*HybridTimestamp, error) {
+	out := new(HybridTimestamp)
+	err := c.cc.Invoke(ctx, HybridTimestampAPI_GetTimestamp_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hybridTimestampAPIClient) GetTimestampRange(ctx context.Context, in *GetTimestampRequest, opts ...grpc.CallOption) (*TimestampRange, error) {
+	out := new(TimestampRange)
+	err := c.cc.Invoke(ctx, HybridTimestampAPI_GetTimestampRange_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hybridTimestampAPIClient) GetReadTimestamp(ctx context.Context, in *GetReadTimestampRequest, opts ...grpc.CallOption) (*HybridTimestamp, error) {
+	out := new(HybridTimestamp)
+	err := c.cc.Invoke(ctx, HybridTimestampAPI_GetReadTimestamp_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hybridTimestampAPIClient) GetSafeReadTimestamp(ctx context.Context, in *GetSafeReadTimestampRequest, opts ...grpc.CallOption) (*SafeReadTimestamp, error) {
+	out := new(SafeReadTimestamp)
+	err := c.cc.Invoke(ctx, HybridTimestampAPI_GetSafeReadTimestamp_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hybridTimestampAPIClient) CommitWait(ctx context.Context, in *CommitWaitRequest, opts ...grpc.CallOption) (*CommitWaitResponse, error) {
+	out := new(CommitWaitResponse)
+	err := c.cc.Invoke(ctx, HybridTimestampAPI_CommitWait_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hybridTimestampAPIClient) Now(ctx context.Context, in *NowRequest, opts ...grpc.CallOption) (*TimeInterval, error) {
+	out := new(TimeInterval)
+	err := c.cc.Invoke(ctx, HybridTimestampAPI_Now_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hybridTimestampAPIClient) NextSequence(ctx context.Context, in *NextSequenceRequest, opts ...grpc.CallOption) (*SequenceRange, error) {
+	out := new(SequenceRange)
+	err := c.cc.Invoke(ctx, HybridTimestampAPI_NextSequence_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hybridTimestampAPIClient) AcquireLock(ctx context.Context, in *AcquireLockRequest, opts ...grpc.CallOption) (*LockLease, error) {
+	out := new(LockLease)
+	err := c.cc.Invoke(ctx, HybridTimestampAPI_AcquireLock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hybridTimestampAPIClient) RenewLock(ctx context.Context, in *RenewLockRequest, opts ...grpc.CallOption) (*LockLease, error) {
+	out := new(LockLease)
+	err := c.cc.Invoke(ctx, HybridTimestampAPI_RenewLock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hybridTimestampAPIClient) ReleaseLock(ctx context.Context, in *ReleaseLockRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, HybridTimestampAPI_ReleaseLock_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hybridTimestampAPIClient) RegisterSafepoint(ctx context.Context, in *RegisterSafepointRequest, opts ...grpc.CallOption) (*Safepoint, error) {
+	out := new(Safepoint)
+	err := c.cc.Invoke(ctx, HybridTimestampAPI_RegisterSafepoint_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hybridTimestampAPIClient) RemoveSafepoint(ctx context.Context, in *RemoveSafepointRequest, opts ...grpc.CallOption) (*Safepoint, error) {
+	out := new(Safepoint)
+	err := c.cc.Invoke(ctx, HybridTimestampAPI_RemoveSafepoint_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hybridTimestampAPIClient) GetSafepoint(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Safepoint, error) {
+	out := new(Safepoint)
+	err := c.cc.Invoke(ctx, HybridTimestampAPI_GetSafepoint_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hybridTimestampAPIClient) GetIDs(ctx context.Context, in *GetIDsRequest, opts ...grpc.CallOption) (*IDs, error) {
+	out := new(IDs)
+	err := c.cc.Invoke(ctx, HybridTimestampAPI_GetIDs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// HybridTimestampAPIServer is the server API for HybridTimestampAPI service.
+// All implementations must embed UnimplementedHybridTimestampAPIServer
+// for forward compatibility
+type HybridTimestampAPIServer interface {
+	GetTimestamp(context.Context, *GetTimestampRequest) (*HybridTimestamp, error)
+	// GetTimestampRange reserves count timestamps without sending each of them, for bulk loading
+	GetTimestampRange(context.Context, *GetTimestampRequest) (*TimestampRange, error)
+	// GetReadTimestamp returns a non-unique timestamp at least as large as every issued timestamp, for snapshot reads
+	GetReadTimestamp(context.Context, *GetReadTimestampRequest) (*HybridTimestamp, error)
+	// GetSafeReadTimestamp can be served by any node, including followers, for stale reads
+	GetSafeReadTimestamp(context.Context, *GetSafeReadTimestampRequest) (*SafeReadTimestamp, error)
+	// CommitWait returns once the committed epoch is past the timestamp, so it can never be served again
+	CommitWait(context.Context, *CommitWaitRequest) (*CommitWaitResponse, error)
+	// Now returns TrueTime-style bounds on the current time, and can be served by any node
+	Now(context.Context, *NowRequest) (*TimeInterval, error)
+	// NextSequence returns the next values of a named sequence, which are strictly increasing but may have gaps.
+	// It must be sent to the leader of the meta raft group.
+	NextSequence(context.Context, *NextSequenceRequest) (*SequenceRange, error)
+	// AcquireLock acquires a lease of a named lock with a fencing token, it must be sent to the leader
+	AcquireLock(context.Context, *AcquireLockRequest) (*LockLease, error)
+	RenewLock(context.Context, *RenewLockRequest) (*LockLease, error)
+	ReleaseLock(context.Context, *ReleaseLockRequest) (*Empty, error)
+	// RegisterSafepoint registers the min active read timestamp of a service, it must be sent to the leader
+	RegisterSafepoint(context.Context, *RegisterSafepointRequest) (*Safepoint, error)
+	RemoveSafepoint(context.Context, *RemoveSafepointRequest) (*Safepoint, error)
+	// GetSafepoint can be served by any node
+	GetSafepoint(context.Context, *Empty) (*Safepoint, error)
+	// GetIDs derives ordered IDs from unique timestamps
+	GetIDs(context.Context, *GetIDsRequest) (*IDs, error)
+	mustEmbedUnimplementedHybridTimestampAPIServer()
+}
+
+// UnimplementedHybridTimestampAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedHybridTimestampAPIServer struct {
+}
+
+func (UnimplementedHybridTimestampAPIServer) GetTimestamp(context.Context, *GetTimestampRequest) (*HybridTimestamp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimestamp not implemented")
+}
+func (UnimplementedHybridTimestampAPIServer) GetTimestampRange(context.Context, *GetTimestampRequest) (*TimestampRange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTimestampRange not implemented")
+}
+func (UnimplementedHybridTimestampAPIServer) GetReadTimestamp(context.Context, *GetReadTimestampRequest) (*HybridTimestamp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReadTimestamp not implemented")
+}
+func (UnimplementedHybridTimestampAPIServer) GetSafeReadTimestamp(context.Context, *GetSafeReadTimestampRequest) (*SafeReadTimestamp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSafeReadTimestamp not implemented")
+}
+func (UnimplementedHybridTimestampAPIServer) CommitWait(context.Context, *CommitWaitRequest) (*CommitWaitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitWait not implemented")
+}
+func (UnimplementedHybridTimestampAPIServer) Now(context.Context, *NowRequest) (*TimeInterval, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Now not implemented")
+}
+func (UnimplementedHybridTimestampAPIServer) NextSequence(context.Context, *NextSequenceRequest) (*SequenceRange, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextSequence not implemented")
+}
+func (UnimplementedHybridTimestampAPIServer) AcquireLock(context.Context, *AcquireLockRequest) (*LockLease, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcquireLock not implemented")
+}
+func (UnimplementedHybridTimestampAPIServer) RenewLock(context.Context, *RenewLockRequest) (*LockLease, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewLock not implemented")
+}
+func (UnimplementedHybridTimestampAPIServer) ReleaseLock(context.Context, *ReleaseLockRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseLock not implemented")
+}
+func (UnimplementedHybridTimestampAPIServer) RegisterSafepoint(context.Context, *RegisterSafepointRequest) (*Safepoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterSafepoint not implemented")
+}
+func (UnimplementedHybridTimestampAPIServer) RemoveSafepoint(context.Context, *RemoveSafepointRequest) (*Safepoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveSafepoint not implemented")
+}
+func (UnimplementedHybridTimestampAPIServer) GetSafepoint(context.Context, *Empty) (*Safepoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSafepoint not implemented")
+}
+func (UnimplementedHybridTimestampAPIServer) GetIDs(context.Context, *GetIDsRequest) (*IDs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIDs not implemented")
+}
+func (UnimplementedHybridTimestampAPIServer) mustEmbedUnimplementedHybridTimestampAPIServer() {}
+
+// UnsafeHybridTimestampAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to HybridTimestampAPIServer will
+// result in compilation errors.
+type UnsafeHybridTimestampAPIServer interface {
+	mustEmbedUnimplementedHybridTimestampAPIServer()
+}
+
+func RegisterHybridTimestampAPIServer(s grpc.ServiceRegistrar, srv HybridTimestampAPIServer) {
+	s.RegisterService(&HybridTimestampAPI_ServiceDesc, srv)
+}
+
+func _HybridTimestampAPI_GetTimestamp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTimestampRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HybridTimestampAPIServer).GetTimestamp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HybridTimestampAPI_GetTimestamp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HybridTimestampAPIServer).GetTimestamp(ctx, req.(*GetTimestampRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HybridTimestampAPI_GetTimestampRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTimestampRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HybridTimestampAPIServer).GetTimestampRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HybridTimestampAPI_GetTimestampRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HybridTimestampAPIServer).GetTimestampRange(ctx, req.(*GetTimestampRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HybridTimestampAPI_GetReadTimestamp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReadTimestampRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HybridTimestampAPIServer).GetReadTimestamp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HybridTimestampAPI_GetReadTimestamp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HybridTimestampAPIServer).GetReadTimestamp(ctx, req.(*GetReadTimestampRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HybridTimestampAPI_GetSafeReadTimestamp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSafeReadTimestampRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HybridTimestampAPIServer).GetSafeReadTimestamp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HybridTimestampAPI_GetSafeReadTimestamp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HybridTimestampAPIServer).GetSafeReadTimestamp(ctx, req.(*GetSafeReadTimestampRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HybridTimestampAPI_CommitWait_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitWaitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HybridTimestampAPIServer).CommitWait(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HybridTimestampAPI_CommitWait_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HybridTimestampAPIServer).CommitWait(ctx, req.(*CommitWaitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HybridTimestampAPI_Now_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HybridTimestampAPIServer).Now(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HybridTimestampAPI_Now_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HybridTimestampAPIServer).Now(ctx, req.(*NowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HybridTimestampAPI_NextSequence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextSequenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HybridTimestampAPIServer).NextSequence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HybridTimestampAPI_NextSequence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HybridTimestampAPIServer).NextSequence(ctx, req.(*NextSequenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HybridTimestampAPI_AcquireLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcquireLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HybridTimestampAPIServer).AcquireLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HybridTimestampAPI_AcquireLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HybridTimestampAPIServer).AcquireLock(ctx, req.(*AcquireLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HybridTimestampAPI_RenewLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HybridTimestampAPIServer).RenewLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HybridTimestampAPI_RenewLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HybridTimestampAPIServer).RenewLock(ctx, req.(*RenewLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HybridTimestampAPI_ReleaseLock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseLockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HybridTimestampAPIServer).ReleaseLock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HybridTimestampAPI_ReleaseLock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HybridTimestampAPIServer).ReleaseLock(ctx, req.(*ReleaseLockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HybridTimestampAPI_RegisterSafepoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterSafepointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HybridTimestampAPIServer).RegisterSafepoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HybridTimestampAPI_RegisterSafepoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HybridTimestampAPIServer).RegisterSafepoint(ctx, req.(*RegisterSafepointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HybridTimestampAPI_RemoveSafepoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveSafepointRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HybridTimestampAPIServer).RemoveSafepoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HybridTimestampAPI_RemoveSafepoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HybridTimestampAPIServer).RemoveSafepoint(ctx, req.(*RemoveSafepointRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HybridTimestampAPI_GetSafepoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HybridTimestampAPIServer).GetSafepoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HybridTimestampAPI_GetSafepoint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HybridTimestampAPIServer).GetSafepoint(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HybridTimestampAPI_GetIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HybridTimestampAPIServer).GetIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HybridTimestampAPI_GetIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HybridTimestampAPIServer).GetIDs(ctx, req.(*GetIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// HybridTimestampAPI_ServiceDesc is the grpc.ServiceDesc for HybridTimestampAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var HybridTimestampAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.v1.HybridTimestampAPI",
+	HandlerType: (*HybridTimestampAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTimestamp",
+			Handler:    _HybridTimestampAPI_GetTimestamp_Handler,
+		},
+		{
+			MethodName: "GetTimestampRange",
+			Handler:    _HybridTimestampAPI_GetTimestampRange_Handler,
+		},
+		{
+			MethodName: "GetReadTimestamp",
+			Handler:    _HybridTimestampAPI_GetReadTimestamp_Handler,
+		},
+		{
+			MethodName: "GetSafeReadTimestamp",
+			Handler:    _HybridTimestampAPI_GetSafeReadTimestamp_Handler,
+		},
+		{
+			MethodName: "CommitWait",
+			Handler:    _HybridTimestampAPI_CommitWait_Handler,
+		},
+		{
+			MethodName: "Now",
+			Handler:    _HybridTimestampAPI_Now_Handler,
+		},
+		{
+			MethodName: "NextSequence",
+			Handler:    _HybridTimestampAPI_NextSequence_Handler,
+		},
+		{
+			MethodName: "AcquireLock",
+			Handler:    _HybridTimestampAPI_AcquireLock_Handler,
+		},
+		{
+			MethodName: "RenewLock",
+			Handler:    _HybridTimestampAPI_RenewLock_Handler,
+		},
+		{
+			MethodName: "ReleaseLock",
+			Handler:    _HybridTimestampAPI_ReleaseLock_Handler,
+		},
+		{
+			MethodName: "RegisterSafepoint",
+			Handler:    _HybridTimestampAPI_RegisterSafepoint_Handler,
+		},
+		{
+			MethodName: "RemoveSafepoint",
+			Handler:    _HybridTimestampAPI_RemoveSafepoint_Handler,
+		},
+		{
+			MethodName: "GetSafepoint",
+			Handler:    _HybridTimestampAPI_GetSafepoint_Handler,
+		},
+		{
+			MethodName: "GetIDs",
+			Handler:    _HybridTimestampAPI_GetIDs_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/v1/api.proto",
+}
@@ -0,0 +1,524 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: api/v1/api.proto
+
+package apiv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/danthegoodman1/EpicEpoch/proto/api/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// HybridTimestampAPIName is the fully-qualified name of the HybridTimestampAPI service.
+	HybridTimestampAPIName = "api.v1.HybridTimestampAPI"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// HybridTimestampAPIGetTimestampProcedure is the fully-qualified name of the HybridTimestampAPI's
+	// GetTimestamp RPC.
+	HybridTimestampAPIGetTimestampProcedure = "/api.v1.HybridTimestampAPI/GetTimestamp"
+	// HybridTimestampAPIGetTimestampRangeProcedure is the fully-qualified name of the
+	// HybridTimestampAPI's GetTimestampRange RPC.
+	HybridTimestampAPIGetTimestampRangeProcedure = "/api.v1.HybridTimestampAPI/GetTimestampRange"
+	// HybridTimestampAPIGetReadTimestampProcedure is the fully-qualified name of the
+	// HybridTimestampAPI's GetReadTimestamp RPC.
+	HybridTimestampAPIGetReadTimestampProcedure = "/api.v1.HybridTimestampAPI/GetReadTimestamp"
+	// HybridTimestampAPIGetSafeReadTimestampProcedure is the fully-qualified name of the
+	// HybridTimestampAPI's GetSafeReadTimestamp RPC.
+	HybridTimestampAPIGetSafeReadTimestampProcedure = "/api.v1.HybridTimestampAPI/GetSafeReadTimestamp"
+	// HybridTimestampAPICommitWaitProcedure is the fully-qualified name of the HybridTimestampAPI's
+	// CommitWait RPC.
+	HybridTimestampAPICommitWaitProcedure = "/api.v1.HybridTimestampAPI/CommitWait"
+	// HybridTimestampAPINowProcedure is the fully-qualified name of the HybridTimestampAPI's Now RPC.
+	HybridTimestampAPINowProcedure = "/api.v1.HybridTimestampAPI/Now"
+	// HybridTimestampAPINextSequenceProcedure is the fully-qualified name of the HybridTimestampAPI's
+	// NextSequence RPC.
+	HybridTimestampAPINextSequenceProcedure = "/api.v1.HybridTimestampAPI/NextSequence"
+	// HybridTimestampAPIAcquireLockProcedure is the fully-qualified name of the HybridTimestampAPI's
+	// AcquireLock RPC.
+	HybridTimestampAPIAcquireLockProcedure = "/api.v1.HybridTimestampAPI/AcquireLock"
+	// HybridTimestampAPIRenewLockProcedure is the fully-qualified name of the HybridTimestampAPI's
+	// RenewLock RPC.
+	HybridTimestampAPIRenewLockProcedure = "/api.v1.HybridTimestampAPI/RenewLock"
+	// HybridTimestampAPIReleaseLockProcedure is the fully-qualified name of the HybridTimestampAPI's
+	// ReleaseLock RPC.
+	HybridTimestampAPIReleaseLockProcedure = "/api.v1.HybridTimestampAPI/ReleaseLock"
+	// HybridTimestampAPIRegisterSafepointProcedure is the fully-qualified name of the
+	// HybridTimestampAPI's RegisterSafepoint RPC.
+	HybridTimestampAPIRegisterSafepointProcedure = "/api.v1.HybridTimestampAPI/RegisterSafepoint"
+	// HybridTimestampAPIRemoveSafepointProcedure is the fully-qualified name of the
+	// HybridTimestampAPI's RemoveSafepoint RPC.
+	HybridTimestampAPIRemoveSafepointProcedure = "/api.v1.HybridTimestampAPI/RemoveSafepoint"
+	// HybridTimestampAPIGetSafepointProcedure is the fully-qualified name of the HybridTimestampAPI's
+	// GetSafepoint RPC.
+	HybridTimestampAPIGetSafepointProcedure = "/api.v1.HybridTimestampAPI/GetSafepoint"
+	// HybridTimestampAPIGetIDsProcedure is the fully-qualified name of the HybridTimestampAPI's GetIDs
+	// RPC.
+	HybridTimestampAPIGetIDsProcedure = "/api.v1.HybridTimestampAPI/GetIDs"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	hybridTimestampAPIServiceDescriptor                    = v1.File_api_v1_api_proto.Services().ByName("HybridTimestampAPI")
+	hybridTimestampAPIGetTimestampMethodDescriptor         = hybridTimestampAPIServiceDescriptor.Methods().ByName("GetTimestamp")
+	hybridTimestampAPIGetTimestampRangeMethodDescriptor    = hybridTimestampAPIServiceDescriptor.Methods().ByName("GetTimestampRange")
+	hybridTimestampAPIGetReadTimestampMethodDescriptor     = hybridTimestampAPIServiceDescriptor.Methods().ByName("GetReadTimestamp")
+	hybridTimestampAPIGetSafeReadTimestampMethodDescriptor = hybridTimestampAPIServiceDescriptor.Methods().ByName("GetSafeReadTimestamp")
+	hybridTimestampAPICommitWaitMethodDescriptor           = hybridTimestampAPIServiceDescriptor.Methods().ByName("CommitWait")
+	hybridTimestampAPINowMethodDescriptor                  = hybridTimestampAPIServiceDescriptor.Methods().ByName("Now")
+	hybridTimestampAPINextSequenceMethodDescriptor         = hybridTimestampAPIServiceDescriptor.Methods().ByName("NextSequence")
+	hybridTimestampAPIAcquireLockMethodDescriptor          = hybridTimestampAPIServiceDescriptor.Methods().ByName("AcquireLock")
+	hybridTimestampAPIRenewLockMethodDescriptor            = hybridTimestampAPIServiceDescriptor.Methods().ByName("RenewLock")
+	hybridTimestampAPIReleaseLockMethodDescriptor          = hybridTimestampAPIServiceDescriptor.Methods().ByName("ReleaseLock")
+	hybridTimestampAPIRegisterSafepointMethodDescriptor    = hybridTimestampAPIServiceDescriptor.Methods().ByName("RegisterSafepoint")
+	hybridTimestampAPIRemoveSafepointMethodDescriptor      = hybridTimestampAPIServiceDescriptor.Methods().ByName("RemoveSafepoint")
+	hybridTimestampAPIGetSafepointMethodDescriptor         = hybridTimestampAPIServiceDescriptor.Methods().ByName("GetSafepoint")
+	hybridTimestampAPIGetIDsMethodDescriptor               = hybridTimestampAPIServiceDescriptor.Methods().ByName("GetIDs")
+)
+
+// HybridTimestampAPIClient is a client for the api.v1.HybridTimestampAPI service.
+type HybridTimestampAPIClient interface {
+	GetTimestamp(context.Context, *connect.Request[v1.GetTimestampRequest]) (*connect.Response[v1.HybridTimestamp], error)
+	// GetTimestampRange reserves count timestamps without sending each of them, for bulk loading
+	GetTimestampRange(context.Context, *connect.Request[v1.GetTimestampRequest]) (*connect.Response[v1.TimestampRange], error)
+	// GetReadTimestamp returns a non-unique timestamp at least as large as every issued timestamp, for snapshot reads
+	GetReadTimestamp(context.Context, *connect.Request[v1.GetReadTimestampRequest]) (*connect.Response[v1.HybridTimestamp], error)
+	// GetSafeReadTimestamp can be served by any node, including followers, for stale reads
+	GetSafeReadTimestamp(context.Context, *connect.Request[v1.GetSafeReadTimestampRequest]) (*connect.Response[v1.SafeReadTimestamp], error)
+	// CommitWait returns once the committed epoch is past the timestamp, so it can never be served again
+	CommitWait(context.Context, *connect.Request[v1.CommitWaitRequest]) (*connect.Response[v1.CommitWaitResponse], error)
+	// Now returns TrueTime-style bounds on the current time, and can be served by any node
+	Now(context.Context, *connect.Request[v1.NowRequest]) (*connect.Response[v1.TimeInterval], error)
+	// NextSequence returns the next values of a named sequence, which are strictly increasing but may have gaps.
+	// It must be sent to the leader of the meta raft group.
+	NextSequence(context.Context, *connect.Request[v1.NextSequenceRequest]) (*connect.Response[v1.SequenceRange], error)
+	// AcquireLock acquires a lease of a named lock with a fencing token, it must be sent to the leader
+	AcquireLock(context.Context, *connect.Request[v1.AcquireLockRequest]) (*connect.Response[v1.LockLease], error)
+	RenewLock(context.Context, *connect.Request[v1.RenewLockRequest]) (*connect.Response[v1.LockLease], error)
+	ReleaseLock(context.Context, *connect.Request[v1.ReleaseLockRequest]) (*connect.Response[v1.Empty], error)
+	// RegisterSafepoint registers the min active read timestamp of a service, it must be sent to the leader
+	RegisterSafepoint(context.Context, *connect.Request[v1.RegisterSafepointRequest]) (*connect.Response[v1.Safepoint], error)
+	RemoveSafepoint(context.Context, *connect.Request[v1.RemoveSafepointRequest]) (*connect.Response[v1.Safepoint], error)
+	// GetSafepoint can be served by any node
+	GetSafepoint(context.Context, *connect.Request[v1.Empty]) (*connect.Response[v1.Safepoint], error)
+	// GetIDs derives ordered IDs from unique timestamps
+	GetIDs(context.Context, *connect.Request[v1.GetIDsRequest]) (*connect.Response[v1.IDs], error)
+}
+
+// NewHybridTimestampAPIClient constructs a client for the api.v1.HybridTimestampAPI service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewHybridTimestampAPIClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) HybridTimestampAPIClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &hybridTimestampAPIClient{
+		getTimestamp: connect.NewClient[v1.GetTimestampRequest, v1.HybridTimestamp](
+			httpClient,
+			baseURL+HybridTimestampAPIGetTimestampProcedure,
+			connect.WithSchema(hybridTimestampAPIGetTimestampMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getTimestampRange: connect.NewClient[v1.GetTimestampRequest, v1.TimestampRange](
+			httpClient,
+			baseURL+HybridTimestampAPIGetTimestampRangeProcedure,
+			connect.WithSchema(hybridTimestampAPIGetTimestampRangeMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getReadTimestamp: connect.NewClient[v1.GetReadTimestampRequest, v1.HybridTimestamp](
+			httpClient,
+			baseURL+HybridTimestampAPIGetReadTimestampProcedure,
+			connect.WithSchema(hybridTimestampAPIGetReadTimestampMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getSafeReadTimestamp: connect.NewClient[v1.GetSafeReadTimestampRequest, v1.SafeReadTimestamp](
+			httpClient,
+			baseURL+HybridTimestampAPIGetSafeReadTimestampProcedure,
+			connect.WithSchema(hybridTimestampAPIGetSafeReadTimestampMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		commitWait: connect.NewClient[v1.CommitWaitRequest, v1.CommitWaitResponse](
+			httpClient,
+			baseURL+HybridTimestampAPICommitWaitProcedure,
+			connect.WithSchema(hybridTimestampAPICommitWaitMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		now: connect.NewClient[v1.NowRequest, v1.TimeInterval](
+			httpClient,
+			baseURL+HybridTimestampAPINowProcedure,
+			connect.WithSchema(hybridTimestampAPINowMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		nextSequence: connect.NewClient[v1.NextSequenceRequest, v1.SequenceRange](
+			httpClient,
+			baseURL+HybridTimestampAPINextSequenceProcedure,
+			connect.WithSchema(hybridTimestampAPINextSequenceMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		acquireLock: connect.NewClient[v1.AcquireLockRequest, v1.LockLease](
+			httpClient,
+			baseURL+HybridTimestampAPIAcquireLockProcedure,
+			connect.WithSchema(hybridTimestampAPIAcquireLockMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		renewLock: connect.NewClient[v1.RenewLockRequest, v1.LockLease](
+			httpClient,
+			baseURL+HybridTimestampAPIRenewLockProcedure,
+			connect.WithSchema(hybridTimestampAPIRenewLockMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		releaseLock: connect.NewClient[v1.ReleaseLockRequest, v1.Empty](
+			httpClient,
+			baseURL+HybridTimestampAPIReleaseLockProcedure,
+			connect.WithSchema(hybridTimestampAPIReleaseLockMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		registerSafepoint: connect.NewClient[v1.RegisterSafepointRequest, v1.Safepoint](
+			httpClient,
+			baseURL+HybridTimestampAPIRegisterSafepointProcedure,
+			connect.WithSchema(hybridTimestampAPIRegisterSafepointMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		removeSafepoint: connect.NewClient[v1.RemoveSafepointRequest, v1.Safepoint](
+			httpClient,
+			baseURL+HybridTimestampAPIRemoveSafepointProcedure,
+			connect.WithSchema(hybridTimestampAPIRemoveSafepointMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getSafepoint: connect.NewClient[v1.Empty, v1.Safepoint](
+			httpClient,
+			baseURL+HybridTimestampAPIGetSafepointProcedure,
+			connect.WithSchema(hybridTimestampAPIGetSafepointMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getIDs: connect.NewClient[v1.GetIDsRequest, v1.IDs](
+			httpClient,
+			baseURL+HybridTimestampAPIGetIDsProcedure,
+			connect.WithSchema(hybridTimestampAPIGetIDsMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// hybridTimestampAPIClient implements HybridTimestampAPIClient.
+type hybridTimestampAPIClient struct {
+	getTimestamp         *connect.Client[v1.GetTimestampRequest, v1.HybridTimestamp]
+	getTimestampRange    *connect.Client[v1.GetTimestampRequest, v1.TimestampRange]
+	getReadTimestamp     *connect.Client[v1.GetReadTimestampRequest, v1.HybridTimestamp]
+	getSafeReadTimestamp *connect.Client[v1.GetSafeReadTimestampRequest, v1.SafeReadTimestamp]
+	commitWait           *connect.Client[v1.CommitWaitRequest, v1.CommitWaitResponse]
+	now                  *connect.Client[v1.NowRequest, v1.TimeInterval]
+	nextSequence         *connect.Client[v1.NextSequenceRequest, v1.SequenceRange]
+	acquireLock          *connect.Client[v1.AcquireLockRequest, v1.LockLease]
+	renewLock            *connect.Client[v1.RenewLockRequest, v1.LockLease]
+	releaseLock          *connect.Client[v1.ReleaseLockRequest, v1.Empty]
+	registerSafepoint    *connect.Client[v1.RegisterSafepointRequest, v1.Safepoint]
+	removeSafepoint      *connect.Client[v1.RemoveSafepointRequest, v1.Safepoint]
+	getSafepoint         *connect.Client[v1.Empty, v1.Safepoint]
+	getIDs               *connect.Client[v1.GetIDsRequest, v1.IDs]
+}
+
+// GetTimestamp calls api.v1.HybridTimestampAPI.GetTimestamp.
+func (c *hybridTimestampAPIClient) GetTimestamp(ctx context.Context, req *connect.Request[v1.GetTimestampRequest]) (*connect.Response[v1.HybridTimestamp], error) {
+	return c.getTimestamp.CallUnary(ctx, req)
+}
+
+// GetTimestampRange calls api.v1.HybridTimestampAPI.GetTimestampRange.
+func (c *hybridTimestampAPIClient) GetTimestampRange(ctx context.Context, req *connect.Request[v1.GetTimestampRequest]) (*connect.Response[v1.TimestampRange], error) {
+	return c.getTimestampRange.CallUnary(ctx, req)
+}
+
+// GetReadTimestamp calls api.v1.HybridTimestampAPI.GetReadTimestamp.
+func (c *hybridTimestampAPIClient) GetReadTimestamp(ctx context.Context, req *connect.Request[v1.GetReadTimestampRequest]) (*connect.Response[v1.HybridTimestamp], error) {
+	return c.getReadTimestamp.CallUnary(ctx, req)
+}
+
+// GetSafeReadTimestamp calls api.v1.HybridTimestampAPI.GetSafeReadTimestamp.
+func (c *hybridTimestampAPIClient) GetSafeReadTimestamp(ctx context.Context, req *connect.Request[v1.GetSafeReadTimestampRequest]) (*connect.Response[v1.SafeReadTimestamp], error) {
+	return c.getSafeReadTimestamp.CallUnary(ctx, req)
+}
+
+// CommitWait calls api.v1.HybridTimestampAPI.CommitWait.
+func (c *hybridTimestampAPIClient) CommitWait(ctx context.Context, req *connect.Request[v1.CommitWaitRequest]) (*connect.Response[v1.CommitWaitResponse], error) {
+	return c.commitWait.CallUnary(ctx, req)
+}
+
+// Now calls api.v1.HybridTimestampAPI.Now.
+func (c *hybridTimestampAPIClient) Now(ctx context.Context, req *connect.Request[v1.NowRequest]) (*connect.Response[v1.TimeInterval], error) {
+	return c.now.CallUnary(ctx, req)
+}
+
+// NextSequence calls api.v1.HybridTimestampAPI.NextSequence.
+func (c *hybridTimestampAPIClient) NextSequence(ctx context.Context, req *connect.Request[v1.NextSequenceRequest]) (*connect.Response[v1.SequenceRange], error) {
+	return c.nextSequence.CallUnary(ctx, req)
+}
+
+// AcquireLock calls api.v1.HybridTimestampAPI.AcquireLock.
+func (c *hybridTimestampAPIClient) AcquireLock(ctx context.Context, req *connect.Request[v1.AcquireLockRequest]) (*connect.Response[v1.LockLease], error) {
+	return c.acquireLock.CallUnary(ctx, req)
+}
+
+// RenewLock calls api.v1.HybridTimestampAPI.RenewLock.
+func (c *hybridTimestampAPIClient) RenewLock(ctx context.Context, req *connect.Request[v1.RenewLockRequest]) (*connect.Response[v1.LockLease], error) {
+	return c.renewLock.CallUnary(ctx, req)
+}
+
+// ReleaseLock calls api.v1.HybridTimestampAPI.ReleaseLock.
+func (c *hybridTimestampAPIClient) ReleaseLock(ctx context.Context, req *connect.Request[v1.ReleaseLockRequest]) (*connect.Response[v1.Empty], error) {
+	return c.releaseLock.CallUnary(ctx, req)
+}
+
+// RegisterSafepoint calls api.v1.HybridTimestampAPI.RegisterSafepoint.
+func (c *hybridTimestampAPIClient) RegisterSafepoint(ctx context.Context, req *connect.Request[v1.RegisterSafepointRequest]) (*connect.Response[v1.Safepoint], error) {
+	return c.registerSafepoint.CallUnary(ctx, req)
+}
+
+// RemoveSafepoint calls api.v1.HybridTimestampAPI.RemoveSafepoint.
+func (c *hybridTimestampAPIClient) RemoveSafepoint(ctx context.Context, req *connect.Request[v1.RemoveSafepointRequest]) (*connect.Response[v1.Safepoint], error) {
+	return c.removeSafepoint.CallUnary(ctx, req)
+}
+
+// GetSafepoint calls api.v1.HybridTimestampAPI.GetSafepoint.
+func (c *hybridTimestampAPIClient) GetSafepoint(ctx context.Context, req *connect.Request[v1.Empty]) (*connect.Response[v1.Safepoint], error) {
+	return c.getSafepoint.CallUnary(ctx, req)
+}
+
+// GetIDs calls api.v1.HybridTimestampAPI.GetIDs.
+func (c *hybridTimestampAPIClient) GetIDs(ctx context.Context, req *connect.Request[v1.GetIDsRequest]) (*connect.Response[v1.IDs], error) {
+	return c.getIDs.CallUnary(ctx, req)
+}
+
+// HybridTimestampAPIHandler is an implementation of the api.v1.HybridTimestampAPI service.
+type HybridTimestampAPIHandler interface {
+	GetTimestamp(context.Context, *connect.Request[v1.GetTimestampRequest]) (*connect.Response[v1.HybridTimestamp], error)
+	// GetTimestampRange reserves count timestamps without sending each of them, for bulk loading
+	GetTimestampRange(context.Context, *connect.Request[v1.GetTimestampRequest]) (*connect.Response[v1.TimestampRange], error)
+	// GetReadTimestamp returns a non-unique timestamp at least as large as every issued timestamp, for snapshot reads
+	GetReadTimestamp(context.Context, *connect.Request[v1.GetReadTimestampRequest]) (*connect.Response[v1.HybridTimestamp], error)
+	// GetSafeReadTimestamp can be served by any node, including followers, for stale reads
+	GetSafeReadTimestamp(context.Context, *connect.Request[v1.GetSafeReadTimestampRequest]) (*connect.Response[v1.SafeReadTimestamp], error)
+	// CommitWait returns once the committed epoch is past the timestamp, so it can never be served again
+	CommitWait(context.Context, *connect.Request[v1.CommitWaitRequest]) (*connect.Response[v1.CommitWaitResponse], error)
+	// Now returns TrueTime-style bounds on the current time, and can be served by any node
+	Now(context.Context, *connect.Request[v1.NowRequest]) (*connect.Response[v1.TimeInterval], error)
+	// NextSequence returns the next values of a named sequence, which are strictly increasing but may have gaps.
+	// It must be sent to the leader of the meta raft group.
+	NextSequence(context.Context, *connect.Request[v1.NextSequenceRequest]) (*connect.Response[v1.SequenceRange], error)
+	// AcquireLock acquires a lease of a named lock with a fencing token, it must be sent to the leader
+	AcquireLock(context.Context, *connect.Request[v1.AcquireLockRequest]) (*connect.Response[v1.LockLease], error)
+	RenewLock(context.Context, *connect.Request[v1.RenewLockRequest]) (*connect.Response[v1.LockLease], error)
+	ReleaseLock(context.Context, *connect.Request[v1.ReleaseLockRequest]) (*connect.Response[v1.Empty], error)
+	// RegisterSafepoint registers the min active read timestamp of a service, it must be sent to the leader
+	RegisterSafepoint(context.Context, *connect.Request[v1.RegisterSafepointRequest]) (*connect.Response[v1.Safepoint], error)
+	RemoveSafepoint(context.Context, *connect.Request[v1.RemoveSafepointRequest]) (*connect.Response[v1.Safepoint], error)
+	// GetSafepoint can be served by any node
+	GetSafepoint(context.Context, *connect.Request[v1.Empty]) (*connect.Response[v1.Safepoint], error)
+	// GetIDs derives ordered IDs from unique timestamps
+	GetIDs(context.Context, *connect.Request[v1.GetIDsRequest]) (*connect.Response[v1.IDs], error)
+}
+
+// NewHybridTimestampAPIHandler builds an HTTP handler from the service implementation. It returns
+// the path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewHybridTimestampAPIHandler(svc HybridTimestampAPIHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	hybridTimestampAPIGetTimestampHandler := connect.NewUnaryHandler(
+		HybridTimestampAPIGetTimestampProcedure,
+		svc.GetTimestamp,
+		connect.WithSchema(hybridTimestampAPIGetTimestampMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	hybridTimestampAPIGetTimestampRangeHandler := connect.NewUnaryHandler(
+		HybridTimestampAPIGetTimestampRangeProcedure,
+		svc.GetTimestampRange,
+		connect.WithSchema(hybridTimestampAPIGetTimestampRangeMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	hybridTimestampAPIGetReadTimestampHandler := connect.NewUnaryHandler(
+		HybridTimestampAPIGetReadTimestampProcedure,
+		svc.GetReadTimestamp,
+		connect.WithSchema(hybridTimestampAPIGetReadTimestampMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	hybridTimestampAPIGetSafeReadTimestampHandler := connect.NewUnaryHandler(
+		HybridTimestampAPIGetSafeReadTimestampProcedure,
+		svc.GetSafeReadTimestamp,
+		connect.WithSchema(hybridTimestampAPIGetSafeReadTimestampMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	hybridTimestampAPICommitWaitHandler := connect.NewUnaryHandler(
+		HybridTimestampAPICommitWaitProcedure,
+		svc.CommitWait,
+		connect.WithSchema(hybridTimestampAPICommitWaitMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	hybridTimestampAPINowHandler := connect.NewUnaryHandler(
+		HybridTimestampAPINowProcedure,
+		svc.Now,
+		connect.WithSchema(hybridTimestampAPINowMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	hybridTimestampAPINextSequenceHandler := connect.NewUnaryHandler(
+		HybridTimestampAPINextSequenceProcedure,
+		svc.NextSequence,
+		connect.WithSchema(hybridTimestampAPINextSequenceMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	hybridTimestampAPIAcquireLockHandler := connect.NewUnaryHandler(
+		HybridTimestampAPIAcquireLockProcedure,
+		svc.AcquireLock,
+		connect.WithSchema(hybridTimestampAPIAcquireLockMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	hybridTimestampAPIRenewLockHandler := connect.NewUnaryHandler(
+		HybridTimestampAPIRenewLockProcedure,
+		svc.RenewLock,
+		connect.WithSchema(hybridTimestampAPIRenewLockMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	hybridTimestampAPIReleaseLockHandler := connect.NewUnaryHandler(
+		HybridTimestampAPIReleaseLockProcedure,
+		svc.ReleaseLock,
+		connect.WithSchema(hybridTimestampAPIReleaseLockMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	hybridTimestampAPIRegisterSafepointHandler := connect.NewUnaryHandler(
+		HybridTimestampAPIRegisterSafepointProcedure,
+		svc.RegisterSafepoint,
+		connect.WithSchema(hybridTimestampAPIRegisterSafepointMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	hybridTimestampAPIRemoveSafepointHandler := connect.NewUnaryHandler(
+		HybridTimestampAPIRemoveSafepointProcedure,
+		svc.RemoveSafepoint,
+		connect.WithSchema(hybridTimestampAPIRemoveSafepointMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	hybridTimestampAPIGetSafepointHandler := connect.NewUnaryHandler(
+		HybridTimestampAPIGetSafepointProcedure,
+		svc.GetSafepoint,
+		connect.WithSchema(hybridTimestampAPIGetSafepointMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	hybridTimestampAPIGetIDsHandler := connect.NewUnaryHandler(
+		HybridTimestampAPIGetIDsProcedure,
+		svc.GetIDs,
+		connect.WithSchema(hybridTimestampAPIGetIDsMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/api.v1.HybridTimestampAPI/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case HybridTimestampAPIGetTimestampProcedure:
+			hybridTimestampAPIGetTimestampHandler.ServeHTTP(w, r)
+		case HybridTimestampAPIGetTimestampRangeProcedure:
+			hybridTimestampAPIGetTimestampRangeHandler.ServeHTTP(w, r)
+		case HybridTimestampAPIGetReadTimestampProcedure:
+			hybridTimestampAPIGetReadTimestampHandler.ServeHTTP(w, r)
+		case HybridTimestampAPIGetSafeReadTimestampProcedure:
+			hybridTimestampAPIGetSafeReadTimestampHandler.ServeHTTP(w, r)
+		case HybridTimestampAPICommitWaitProcedure:
+			hybridTimestampAPICommitWaitHandler.ServeHTTP(w, r)
+		case HybridTimestampAPINowProcedure:
+			hybridTimestampAPINowHandler.ServeHTTP(w, r)
+		case HybridTimestampAPINextSequenceProcedure:
+			hybridTimestampAPINextSequenceHandler.ServeHTTP(w, r)
+		case HybridTimestampAPIAcquireLockProcedure:
+			hybridTimestampAPIAcquireLockHandler.ServeHTTP(w, r)
+		case HybridTimestampAPIRenewLockProcedure:
+			hybridTimestampAPIRenewLockHandler.ServeHTTP(w, r)
+		case HybridTimestampAPIReleaseLockProcedure:
+			hybridTimestampAPIReleaseLockHandler.ServeHTTP(w, r)
+		case HybridTimestampAPIRegisterSafepointProcedure:
+			hybridTimestampAPIRegisterSafepointHandler.ServeHTTP(w, r)
+		case HybridTimestampAPIRemoveSafepointProcedure:
+			hybridTimestampAPIRemoveSafepointHandler.ServeHTTP(w, r)
+		case HybridTimestampAPIGetSafepointProcedure:
+			hybridTimestampAPIGetSafepointHandler.ServeHTTP(w, r)
+		case HybridTimestampAPIGetIDsProcedure:
+			hybridTimestampAPIGetIDsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedHybridTimestampAPIHandler returns CodeUnimplemented from all methods.
+type UnimplementedHybridTimestampAPIHandler struct{}
+
+func (UnimplementedHybridTimestampAPIHandler) GetTimestamp(context.Context, *connect.Request[v1.GetTimestampRequest]) (*connect.Response[v1.HybridTimestamp], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.HybridTimestampAPI.GetTimestamp is not implemented"))
+}
+
+func (UnimplementedHybridTimestampAPIHandler) GetTimestampRange(context.Context, *connect.Request[v1.GetTimestampRequest]) (*connect.Response[v1.TimestampRange], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.HybridTimestampAPI.GetTimestampRange is not implemented"))
+}
+
+func (UnimplementedHybridTimestampAPIHandler) GetReadTimestamp(context.Context, *connect.Request[v1.GetReadTimestampRequest]) (*connect.Response[v1.HybridTimestamp], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.HybridTimestampAPI.GetReadTimestamp is not implemented"))
+}
+
+func (UnimplementedHybridTimestampAPIHandler) GetSafeReadTimestamp(context.Context, *connect.Request[v1.GetSafeReadTimestampRequest]) (*connect.Response[v1.SafeReadTimestamp], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.HybridTimestampAPI.GetSafeReadTimestamp is not implemented"))
+}
+
+func (UnimplementedHybridTimestampAPIHandler) CommitWait(context.Context, *connect.Request[v1.CommitWaitRequest]) (*connect.Response[v1.CommitWaitResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.HybridTimestampAPI.CommitWait is not implemented"))
+}
+
+func (UnimplementedHybridTimestampAPIHandler) Now(context.Context, *connect.Request[v1.NowRequest]) (*connect.Response[v1.TimeInterval], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.HybridTimestampAPI.Now is not implemented"))
+}
+
+func (UnimplementedHybridTimestampAPIHandler) NextSequence(context.Context, *connect.Request[v1.NextSequenceRequest]) (*connect.Response[v1.SequenceRange], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.HybridTimestampAPI.NextSequence is not implemented"))
+}
+
+func (UnimplementedHybridTimestampAPIHandler) AcquireLock(context.Context, *connect.Request[v1.AcquireLockRequest]) (*connect.Response[v1.LockLease], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.HybridTimestampAPI.AcquireLock is not implemented"))
+}
+
+func (UnimplementedHybridTimestampAPIHandler) RenewLock(context.Context, *connect.Request[v1.RenewLockRequest]) (*connect.Response[v1.LockLease], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.HybridTimestampAPI.RenewLock is not implemented"))
+}
+
+func (UnimplementedHybridTimestampAPIHandler) ReleaseLock(context.Context, *connect.Request[v1.ReleaseLockRequest]) (*connect.Response[v1.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.HybridTimestampAPI.ReleaseLock is not implemented"))
+}
+
+func (UnimplementedHybridTimestampAPIHandler) RegisterSafepoint(context.Context, *connect.Request[v1.RegisterSafepointRequest]) (*connect.Response[v1.Safepoint], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.HybridTimestampAPI.RegisterSafepoint is not implemented"))
+}
+
+func (UnimplementedHybridTimestampAPIHandler) RemoveSafepoint(context.Context, *connect.Request[v1.RemoveSafepointRequest]) (*connect.Response[v1.Safepoint], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.HybridTimestampAPI.RemoveSafepoint is not implemented"))
+}
+
+func (UnimplementedHybridTimestampAPIHandler) GetSafepoint(context.Context, *connect.Request[v1.Empty]) (*connect.Response[v1.Safepoint], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.HybridTimestampAPI.GetSafepoint is not implemented"))
+}
+
+func (UnimplementedHybridTimestampAPIHandler) GetIDs(context.Context, *connect.Request[v1.GetIDsRequest]) (*connect.Response[v1.IDs], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("api.v1.HybridTimestampAPI.GetIDs is not implemented"))
+}
@@ -0,0 +1,223 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: pdpb/pdpb.proto
+
+// The subset of PD's pdpb (github.com/pingcap/kvproto) that TSO clients use, with the same package, service,
+// and field numbers so existing PD clients can use EpicEpoch as their timestamp oracle
+
+package pdpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PD_GetMembers_FullMethodName     = "/pdpb.PD/GetMembers"
+	PD_Tso_FullMethodName            = "/pdpb.PD/Tso"
+	PD_GetClusterInfo_FullMethodName = "/pdpb.PD/GetClusterInfo"
+)
+
+// PDClient is the client API for PD service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PDClient interface {
+	// GetMembers returns the members of the cluster and the leader, which serves Tso
+	GetMembers(ctx context.Context, in *GetMembersRequest, opts ...grpc.CallOption) (*GetMembersResponse, error)
+	Tso(ctx context.Context, opts ...grpc.CallOption) (PD_TsoClient, error)
+	// GetClusterInfo returns the service mode of the cluster, which clients check before finding the TSO leader
+	GetClusterInfo(ctx context.Context, in *GetClusterInfoRequest, opts ...grpc.CallOption) (*GetClusterInfoResponse, error)
+}
+
+type pDClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPDClient(cc grpc.ClientConnInterface) PDClient {
+	return &pDClient{cc}
+}
+
+func (c *pDClient) GetMembers(ctx context.Context, in *GetMembersRequest, opts ...grpc.CallOption) (*GetMembersResponse, error) {
+	out := new(GetMembersResponse)
+	err := c.cc.Invoke(ctx, PD_GetMembers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pDClient) Tso(ctx context.Context, opts ...grpc.CallOption) (PD_TsoClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PD_ServiceDesc.Streams[0], PD_Tso_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pDTsoClient{stream}
+	return x, nil
+}
+
+type PD_TsoClient interface {
+	Send(*TsoRequest) error
+	Recv() (*TsoResponse, error)
+	grpc.ClientStream
+}
+
+type pDTsoClient struct {
+	grpc.ClientStream
+}
+
+func (x *pDTsoClient) Send(m *TsoRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *pDTsoClient) Recv() (*TsoResponse, error) {
+	m := new(TsoResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *pDClient) GetClusterInfo(ctx context.Context, in *GetClusterInfoRequest, opts ...grpc.CallOption) (*GetClusterInfoResponse, error) {
+	out := new(GetClusterInfoResponse)
+	err := c.cc.Invoke(ctx, PD_GetClusterInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PDServer is the server API for PD service.
+// All implementations must embed UnimplementedPDServer
+// for forward compatibility
+type PDServer interface {
+	// GetMembers returns the members of the cluster and the leader, which serves Tso
+	GetMembers(context.Context, *GetMembersRequest) (*GetMembersResponse, error)
+	Tso(PD_TsoServer) error
+	// GetClusterInfo returns the service mode of the cluster, which clients check before finding the TSO leader
+	GetClusterInfo(context.Context, *GetClusterInfoRequest) (*GetClusterInfoResponse, error)
+	mustEmbedUnimplementedPDServer()
+}
+
+// UnimplementedPDServer must be embedded to have forward compatible implementations.
+type UnimplementedPDServer struct {
+}
+
+func (UnimplementedPDServer) GetMembers(context.Context, *GetMembersRequest) (*GetMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMembers not implemented")
+}
+func (UnimplementedPDServer) Tso(PD_TsoServer) error {
+	return status.Errorf(codes.Unimplemented, "method Tso not implemented")
+}
+func (UnimplementedPDServer) GetClusterInfo(context.Context, *GetClusterInfoRequest) (*GetClusterInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClusterInfo not implemented")
+}
+func (UnimplementedPDServer) mustEmbedUnimplementedPDServer() {}
+
+// UnsafePDServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PDServer will
+// result in compilation errors.
+type UnsafePDServer interface {
+	mustEmbedUnimplementedPDServer()
+}
+
+func RegisterPDServer(s grpc.ServiceRegistrar, srv PDServer) {
+	s.RegisterService(&PD_ServiceDesc, srv)
+}
+
+func _PD_GetMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PDServer).GetMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PD_GetMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).GetMembers(ctx, req.(*GetMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PD_Tso_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PDServer).Tso(&pDTsoServer{stream})
+}
+
+type PD_TsoServer interface {
+	Send(*TsoResponse) error
+	Recv() (*TsoRequest, error)
+	grpc.ServerStream
+}
+
+type pDTsoServer struct {
+	grpc.ServerStream
+}
+
+func (x *pDTsoServer) Send(m *TsoResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *pDTsoServer) Recv() (*TsoRequest, error) {
+	m := new(TsoRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _PD_GetClusterInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClusterInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PDServer).GetClusterInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PD_GetClusterInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PDServer).GetClusterInfo(ctx, req.(*GetClusterInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PD_ServiceDesc is the grpc.ServiceDesc for PD service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PD_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pdpb.PD",
+	HandlerType: (*PDServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMembers",
+			Handler:    _PD_GetMembers_Handler,
+		},
+		{
+			MethodName: "GetClusterInfo",
+			Handler:    _PD_GetClusterInfo_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Tso",
+			Handler:       _PD_Tso_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "pdpb/pdpb.proto",
+}
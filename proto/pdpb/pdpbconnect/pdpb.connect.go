@@ -0,0 +1,176 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: pdpb/pdpb.proto
+
+// The subset of PD's pdpb (github.com/pingcap/kvproto) that TSO clients use, with the same package, service,
+// and field numbers so existing PD clients can use EpicEpoch as their timestamp oracle
+package pdpbconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	pdpb "github.com/danthegoodman1/EpicEpoch/proto/pdpb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// PDName is the fully-qualified name of the PD service.
+	PDName = "pdpb.PD"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// PDGetMembersProcedure is the fully-qualified name of the PD's GetMembers RPC.
+	PDGetMembersProcedure = "/pdpb.PD/GetMembers"
+	// PDTsoProcedure is the fully-qualified name of the PD's Tso RPC.
+	PDTsoProcedure = "/pdpb.PD/Tso"
+	// PDGetClusterInfoProcedure is the fully-qualified name of the PD's GetClusterInfo RPC.
+	PDGetClusterInfoProcedure = "/pdpb.PD/GetClusterInfo"
+)
+
+// These variables are the protoreflect.Descriptor objects for the RPCs defined in this package.
+var (
+	pDServiceDescriptor              = pdpb.File_pdpb_pdpb_proto.Services().ByName("PD")
+	pDGetMembersMethodDescriptor     = pDServiceDescriptor.Methods().ByName("GetMembers")
+	pDTsoMethodDescriptor            = pDServiceDescriptor.Methods().ByName("Tso")
+	pDGetClusterInfoMethodDescriptor = pDServiceDescriptor.Methods().ByName("GetClusterInfo")
+)
+
+// PDClient is a client for the pdpb.PD service.
+type PDClient interface {
+	// GetMembers returns the members of the cluster and the leader, which serves Tso
+	GetMembers(context.Context, *connect.Request[pdpb.GetMembersRequest]) (*connect.Response[pdpb.GetMembersResponse], error)
+	Tso(context.Context) *connect.BidiStreamForClient[pdpb.TsoRequest, pdpb.TsoResponse]
+	// GetClusterInfo returns the service mode of the cluster, which clients check before finding the TSO leader
+	GetClusterInfo(context.Context, *connect.Request[pdpb.GetClusterInfoRequest]) (*connect.Response[pdpb.GetClusterInfoResponse], error)
+}
+
+// NewPDClient constructs a client for the pdpb.PD service. By default, it uses the Connect protocol
+// with the binary Protobuf Codec, asks for gzipped responses, and sends uncompressed requests. To
+// use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or connect.WithGRPCWeb()
+// options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewPDClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) PDClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &pDClient{
+		getMembers: connect.NewClient[pdpb.GetMembersRequest, pdpb.GetMembersResponse](
+			httpClient,
+			baseURL+PDGetMembersProcedure,
+			connect.WithSchema(pDGetMembersMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		tso: connect.NewClient[pdpb.TsoRequest, pdpb.TsoResponse](
+			httpClient,
+			baseURL+PDTsoProcedure,
+			connect.WithSchema(pDTsoMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+		getClusterInfo: connect.NewClient[pdpb.GetClusterInfoRequest, pdpb.GetClusterInfoResponse](
+			httpClient,
+			baseURL+PDGetClusterInfoProcedure,
+			connect.WithSchema(pDGetClusterInfoMethodDescriptor),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// pDClient implements PDClient.
+type pDClient struct {
+	getMembers     *connect.Client[pdpb.GetMembersRequest, pdpb.GetMembersResponse]
+	tso            *connect.Client[pdpb.TsoRequest, pdpb.TsoResponse]
+	getClusterInfo *connect.Client[pdpb.GetClusterInfoRequest, pdpb.GetClusterInfoResponse]
+}
+
+// GetMembers calls pdpb.PD.GetMembers.
+func (c *pDClient) GetMembers(ctx context.Context, req *connect.Request[pdpb.GetMembersRequest]) (*connect.Response[pdpb.GetMembersResponse], error) {
+	return c.getMembers.CallUnary(ctx, req)
+}
+
+// Tso calls pdpb.PD.Tso.
+func (c *pDClient) Tso(ctx context.Context) *connect.BidiStreamForClient[pdpb.TsoRequest, pdpb.TsoResponse] {
+	return c.tso.CallBidiStream(ctx)
+}
+
+// GetClusterInfo calls pdpb.PD.GetClusterInfo.
+func (c *pDClient) GetClusterInfo(ctx context.Context, req *connect.Request[pdpb.GetClusterInfoRequest]) (*connect.Response[pdpb.GetClusterInfoResponse], error) {
+	return c.getClusterInfo.CallUnary(ctx, req)
+}
+
+// PDHandler is an implementation of the pdpb.PD service.
+type PDHandler interface {
+	// GetMembers returns the members of the cluster and the leader, which serves Tso
+	GetMembers(context.Context, *connect.Request[pdpb.GetMembersRequest]) (*connect.Response[pdpb.GetMembersResponse], error)
+	Tso(context.Context, *connect.BidiStream[pdpb.TsoRequest, pdpb.TsoResponse]) error
+	// GetClusterInfo returns the service mode of the cluster, which clients check before finding the TSO leader
+	GetClusterInfo(context.Context, *connect.Request[pdpb.GetClusterInfoRequest]) (*connect.Response[pdpb.GetClusterInfoResponse], error)
+}
+
+// NewPDHandler builds an HTTP handler from the service implementation. It returns the path on which
+// to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewPDHandler(svc PDHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	pDGetMembersHandler := connect.NewUnaryHandler(
+		PDGetMembersProcedure,
+		svc.GetMembers,
+		connect.WithSchema(pDGetMembersMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	pDTsoHandler := connect.NewBidiStreamHandler(
+		PDTsoProcedure,
+		svc.Tso,
+		connect.WithSchema(pDTsoMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	pDGetClusterInfoHandler := connect.NewUnaryHandler(
+		PDGetClusterInfoProcedure,
+		svc.GetClusterInfo,
+		connect.WithSchema(pDGetClusterInfoMethodDescriptor),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/pdpb.PD/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case PDGetMembersProcedure:
+			pDGetMembersHandler.ServeHTTP(w, r)
+		case PDTsoProcedure:
+			pDTsoHandler.ServeHTTP(w, r)
+		case PDGetClusterInfoProcedure:
+			pDGetClusterInfoHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedPDHandler returns CodeUnimplemented from all methods.
+type UnimplementedPDHandler struct{}
+
+func (UnimplementedPDHandler) GetMembers(context.Context, *connect.Request[pdpb.GetMembersRequest]) (*connect.Response[pdpb.GetMembersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("pdpb.PD.GetMembers is not implemented"))
+}
+
+func (UnimplementedPDHandler) Tso(context.Context, *connect.BidiStream[pdpb.TsoRequest, pdpb.TsoResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("pdpb.PD.Tso is not implemented"))
+}
+
+func (UnimplementedPDHandler) GetClusterInfo(context.Context, *connect.Request[pdpb.GetClusterInfoRequest]) (*connect.Response[pdpb.GetClusterInfoResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("pdpb.PD.GetClusterInfo is not implemented"))
+}
@@ -0,0 +1,68 @@
+package quic_server
+
+import "sync"
+
+// responseCache remembers the responses to a connection's most recent request IDs, so a retried request
+// gets the response it was already issued instead of new timestamps
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[uint64]*cachedResponse
+	// order is a ring of the cached request IDs, the oldest is evicted when a new one is cached
+	order []cachedID
+	next  int
+	seq   uint64
+}
+
+type cachedResponse struct {
+	seq uint64
+	// frame is nil while the request is in flight
+	frame []byte
+}
+
+type cachedID struct {
+	id  uint64
+	seq uint64
+}
+
+func newResponseCache(size int) *responseCache {
+	return &responseCache{
+		entries: map[uint64]*cachedResponse{},
+		order:   make([]cachedID, size),
+	}
+}
+
+// begin returns true if the request ID is new and should be handled. Otherwise it returns the cached response,
+// or nil if the request is still in flight and its response will be sent when it's done.
+func (c *responseCache) begin(id uint64) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[id]; ok {
+		return entry.frame, false
+	}
+
+	// Evict the oldest, unless it was already removed and its ID was reused since
+	oldest := c.order[c.next]
+	if entry, ok := c.entries[oldest.id]; ok && entry.seq == oldest.seq {
+		delete(c.entries, oldest.id)
+	}
+	c.seq++
+	c.entries[id] = &cachedResponse{seq: c.seq}
+	c.order[c.next] = cachedID{id: id, seq: c.seq}
+	c.next = (c.next + 1) % len(c.order)
+	return nil, true
+}
+
+// finish caches the response of a request, or forgets the request if it should be handled again when retried
+func (c *responseCache) finish(id uint64, frame []byte, cache bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[id]
+	if !ok {
+		return
+	}
+	if cache {
+		entry.frame = frame
+	} else {
+		delete(c.entries, id)
+	}
+}
@@ -0,0 +1,213 @@
+package quic_server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+)
+
+// DefaultRetryInterval is how long a client waits for a response before sending the request again
+const DefaultRetryInterval = 50 * time.Millisecond
+
+var (
+	// ErrTryAgain is returned when the node shed the request, it should be retried later
+	ErrTryAgain = errors.New("try again")
+	// ErrRequestFailed is returned when the node rejected the request or failed to serve it
+	ErrRequestFailed = errors.New("request failed")
+	ErrClientClosed  = errors.New("client closed")
+)
+
+// NotLeaderError is returned by a node that is not the leader, Addr is the leader's address or empty if the node doesn't know it
+type NotLeaderError struct {
+	Addr string
+}
+
+func (e *NotLeaderError) Error() string {
+	if e.Addr == "" {
+		return "node is not the leader"
+	}
+	return fmt.Sprintf("node is not the leader, the leader is at %s", e.Addr)
+}
+
+type response struct {
+	status  Status
+	payload []byte
+}
+
+// Client sends requests as datagrams on one QUIC connection to a node's QUIC_PORT, resending each every
+// RetryInterval until its response arrives. It's safe for concurrent use.
+type Client struct {
+	// RetryInterval is how long to wait for a response before sending the request again, DefaultRetryInterval if not set
+	RetryInterval time.Duration
+
+	conn    quic.Connection
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[uint64]chan response
+	// err is why the connection closed, once it has
+	err error
+}
+
+// Dial connects to the node at addr. The nodes use a self-signed certificate unless cert.pem and key.pem
+// are provided, which tlsConf must trust (or skip verifying).
+func Dial(ctx context.Context, addr string, tlsConf *tls.Config) (*Client, error) {
+	if tlsConf == nil {
+		tlsConf = &tls.Config{}
+	}
+	tlsConf = tlsConf.Clone()
+	tlsConf.NextProtos = []string{ALPN}
+	conn, err := quic.DialAddr(ctx, addr, tlsConf, &quic.Config{EnableDatagrams: true})
+	if err != nil {
+		return nil, fmt.Errorf("error dialing %s: %w", addr, err)
+	}
+	if !conn.ConnectionState().SupportsDatagrams {
+		conn.CloseWithError(0, "datagrams are required")
+		return nil, fmt.Errorf("%s does not support datagrams", addr)
+	}
+
+	c := &Client{
+		conn:    conn,
+		pending: map[uint64]chan response{},
+	}
+	go c.readLoop()
+	return c, nil
+}
+
+// readLoop delivers every response to the request waiting for it, until the connection fails
+func (c *Client) readLoop() {
+	for {
+		b, err := c.conn.ReceiveDatagram(context.Background())
+		if err != nil {
+			c.fail(err)
+			return
+		}
+		id, status, payload, err := decodeResponse(b)
+		if err != nil {
+			continue
+		}
+
+		c.mu.Lock()
+		ch, ok := c.pending[id]
+		delete(c.pending, id)
+		c.mu.Unlock()
+		// Responses to retries that were already answered, or requests whose context was done, are dropped
+		if ok {
+			ch <- response{status: status, payload: payload}
+		}
+	}
+}
+
+// fail closes the client with err, failing every pending request
+func (c *Client) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	for id, ch := range c.pending {
+		close(ch)
+		delete(c.pending, id)
+	}
+	c.conn.CloseWithError(0, "")
+}
+
+// closedErr is the error for requests on a closed client, c.mu must be held
+func (c *Client) closedErr() error {
+	if errors.Is(c.err, ErrClientClosed) {
+		return c.err
+	}
+	return fmt.Errorf("%w: %w", ErrClientClosed, c.err)
+}
+
+// GetTimestamps gets count sequential unique timestamps, each in its binary form: 16 bytes, or 8 if the cluster
+// uses the packed layout. The request is resent until a response arrives or ctx is done, and the node issues
+// its timestamps only once.
+func (c *Client) GetTimestamps(ctx context.Context, count int) ([][]byte, error) {
+	if count < 1 || uint64(count) > uint64(^uint32(0)) {
+		return nil, fmt.Errorf("%w: invalid count %d", ErrRequestFailed, count)
+	}
+
+	// Buffered so the read loop never blocks on a request that gave up
+	ch := make(chan response, 1)
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return nil, c.closedErr()
+	}
+	c.nextID++
+	id := c.nextID
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	res, err := c.roundTrip(ctx, id, encodeRequest(id, uint32(count)), ch)
+	if err != nil {
+		return nil, err
+	}
+
+	switch res.status {
+	case StatusOK:
+		timestamps, err := expandTimestamps(res.payload, count)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrRequestFailed, err)
+		}
+		return timestamps, nil
+	case StatusNotLeader:
+		return nil, &NotLeaderError{Addr: string(res.payload)}
+	case StatusTryAgain:
+		return nil, fmt.Errorf("%w: %s", ErrTryAgain, res.payload)
+	case StatusError:
+		return nil, fmt.Errorf("%w: %s", ErrRequestFailed, res.payload)
+	}
+	return nil, fmt.Errorf("%w: unknown status %d", ErrRequestFailed, res.status)
+}
+
+// roundTrip sends the request every RetryInterval until its response arrives
+func (c *Client) roundTrip(ctx context.Context, id uint64, req []byte, ch chan response) (response, error) {
+	retryInterval := c.RetryInterval
+	if retryInterval <= 0 {
+		retryInterval = DefaultRetryInterval
+	}
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+
+	for {
+		// A datagram that can't be sent is treated as lost
+		c.conn.SendDatagram(req)
+		select {
+		case res, ok := <-ch:
+			if !ok {
+				c.mu.Lock()
+				defer c.mu.Unlock()
+				return res, c.closedErr()
+			}
+			return res, nil
+		case <-ticker.C:
+		case <-ctx.Done():
+			c.mu.Lock()
+			delete(c.pending, id)
+			c.mu.Unlock()
+			return response{}, ctx.Err()
+		}
+	}
+}
+
+// GetTimestamp gets a single unique timestamp
+func (c *Client) GetTimestamp(ctx context.Context) ([]byte, error) {
+	timestamps, err := c.GetTimestamps(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	return timestamps[0], nil
+}
+
+// Close closes the connection, failing every pending request
+func (c *Client) Close() error {
+	c.fail(ErrClientClosed)
+	return nil
+}
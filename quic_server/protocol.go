@@ -0,0 +1,90 @@
+package quic_server
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+)
+
+// Every request and response is a single QUIC datagram, so nothing is retransmitted by QUIC and the client
+// retries requests whose response doesn't arrive.
+//
+// A request is an 8 byte request ID followed by a 4 byte count of timestamps.
+//
+// A response is the 8 byte request ID it answers, a 1 byte Status, and a payload that depends on the status.
+// A retried request ID gets the same timestamps again instead of new ones.
+
+// ALPN is the TLS application protocol clients must negotiate
+const ALPN = "epicepoch-datagram"
+
+// Status is the outcome of a request, which decides what the response payload is
+type Status uint8
+
+const (
+	// StatusOK responses carry the first of the count sequential timestamps, 16 bytes or 8 in the packed layout
+	StatusOK Status = iota
+	// StatusNotLeader responses carry the leader's address from QUIC_ADVERTISE_ADDRS, or nothing if it's unknown
+	StatusNotLeader
+	// StatusTryAgain responses carry an error message, the request was shed and should be retried later
+	StatusTryAgain
+	// StatusError responses carry an error message, the request is invalid or failed
+	StatusError
+)
+
+const (
+	requestSize        = 12
+	responseHeaderSize = 9
+)
+
+func encodeRequest(id uint64, count uint32) []byte {
+	b := make([]byte, 0, requestSize)
+	b = binary.BigEndian.AppendUint64(b, id)
+	return binary.BigEndian.AppendUint32(b, count)
+}
+
+func decodeRequest(b []byte) (uint64, uint32, error) {
+	if len(b) != requestSize {
+		return 0, 0, fmt.Errorf("request must be %d bytes, got %d", requestSize, len(b))
+	}
+	return binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint32(b[8:]), nil
+}
+
+func encodeResponse(id uint64, status Status, payload []byte) []byte {
+	b := make([]byte, 0, responseHeaderSize+len(payload))
+	b = binary.BigEndian.AppendUint64(b, id)
+	b = append(b, byte(status))
+	return append(b, payload...)
+}
+
+func decodeResponse(b []byte) (uint64, Status, []byte, error) {
+	if len(b) < responseHeaderSize {
+		return 0, 0, nil, fmt.Errorf("response must be at least %d bytes, got %d", responseHeaderSize, len(b))
+	}
+	return binary.BigEndian.Uint64(b[:8]), Status(b[8]), b[responseHeaderSize:], nil
+}
+
+// expandTimestamps returns the count sequential timestamps starting at first, which is either a hybrid
+// or a packed timestamp. Sequential timestamps never carry out of the index, so both are incremented as integers.
+func expandTimestamps(first []byte, count int) ([][]byte, error) {
+	timestamps := make([][]byte, count)
+	switch len(first) {
+	case timestamp.Size:
+		ts, err := timestamp.FromBytes(first)
+		if err != nil {
+			return nil, err
+		}
+		r := timestamp.Range{Epoch: ts.Epoch, StartIndex: ts.Index, Count: uint64(count)}
+		for i := range timestamps {
+			timestamps[i] = r.At(uint64(i)).Bytes()
+		}
+	case timestamp.PackedSize:
+		v := binary.BigEndian.Uint64(first)
+		for i := range timestamps {
+			timestamps[i] = binary.BigEndian.AppendUint64(nil, v+uint64(i))
+		}
+	default:
+		return nil, fmt.Errorf("timestamp must be %d or %d bytes, got %d", timestamp.Size, timestamp.PackedSize, len(first))
+	}
+	return timestamps, nil
+}
@@ -0,0 +1,173 @@
+package quic_server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/frontend"
+	"github.com/danthegoodman1/EpicEpoch/gologger"
+	"github.com/danthegoodman1/EpicEpoch/raft"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+	"github.com/quic-go/quic-go"
+)
+
+var logger = gologger.NewLogger()
+
+const (
+	// maxInFlight is the most requests a connection can have in flight, more are not received until one finishes
+	maxInFlight = 1024
+	// responseCacheSize is how many of a connection's most recent request IDs can be retried without issuing again
+	responseCacheSize = 4096
+)
+
+// QUICServer serves timestamps from the first shard of the default namespace over QUIC datagrams,
+// for the lowest latency over lossy links
+type QUICServer struct {
+	Oracle   *raft.Oracle
+	listener *quic.Listener
+	// advertiseAddrs are the addresses clients reach each node's QUIC listener at, by node ID
+	advertiseAddrs map[uint64]string
+	conns          *frontend.Conns
+}
+
+// StartQUICServer starts the datagram listener on QUIC_PORT (UDP), or returns nil if it is not set
+func StartQUICServer(oracle *raft.Oracle) *QUICServer {
+	if utils.QUICPort == "" {
+		return nil
+	}
+	advertiseAddrs, err := utils.ParseNodeAddrs(utils.QUICAdvertiseAddrs)
+	if err != nil {
+		logger.Error().Err(err).Msg("error parsing QUIC_ADVERTISE_ADDRS, exiting")
+		os.Exit(1)
+	}
+	tlsCert, err := utils.GenerateTLSCert()
+	if err != nil {
+		logger.Fatal().Err(err).Msg("failed to generate self-signed cert")
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{tlsCert},
+		NextProtos:   []string{ALPN},
+	}
+	listener, err := quic.ListenAddr(fmt.Sprintf(":%s", utils.QUICPort), tlsConfig, &quic.Config{EnableDatagrams: true})
+	if err != nil {
+		logger.Error().Err(err).Msg("error creating quic listener, exiting")
+		os.Exit(1)
+	}
+
+	s := &QUICServer{
+		Oracle:         oracle,
+		listener:       listener,
+		advertiseAddrs: advertiseAddrs,
+		conns:          frontend.NewConns("quic", listener),
+	}
+
+	logger.Info().Msg("starting quic datagram server on " + listener.Addr().String())
+	go frontend.AcceptLoop(s.conns, func() (quic.Connection, error) {
+		return listener.Accept(s.conns.Ctx)
+	}, s.serve)
+
+	return s
+}
+
+// serve handles every request datagram on the connection concurrently, sending each response as it's ready.
+// Requests are never retransmitted by QUIC, and retries of a request ID that was already answered get the
+// same response from the cache.
+func (s *QUICServer) serve(conn quic.Connection) {
+	if !conn.ConnectionState().SupportsDatagrams {
+		conn.CloseWithError(0, "datagrams are required")
+		return
+	}
+
+	cache := newResponseCache(responseCacheSize)
+	sem := make(chan struct{}, maxInFlight)
+	var inFlight sync.WaitGroup
+	defer inFlight.Wait()
+	for {
+		b, err := conn.ReceiveDatagram(s.conns.Ctx)
+		if err != nil {
+			return
+		}
+		id, count, err := decodeRequest(b)
+		if err != nil {
+			logger.Debug().Err(err).Msg("dropping invalid quic datagram")
+			continue
+		}
+
+		frame, isNew := cache.begin(id)
+		if !isNew {
+			// A request still in flight will be answered when it's done
+			if frame != nil {
+				s.send(conn, frame)
+			}
+			continue
+		}
+
+		sem <- struct{}{}
+		inFlight.Add(1)
+		go func() {
+			defer inFlight.Done()
+			status, payload := s.handle(id, count)
+			frame := encodeResponse(id, status, payload)
+			// Only issued timestamps are cached, retries of other responses are handled again
+			cache.finish(id, frame, status == StatusOK)
+			s.send(conn, frame)
+			<-sem
+		}()
+	}
+}
+
+func (s *QUICServer) send(conn quic.Connection, frame []byte) {
+	if err := conn.SendDatagram(frame); err != nil {
+		// The client will retry
+		logger.Debug().Err(err).Msg("error sending quic datagram")
+	}
+}
+
+func (s *QUICServer) handle(id uint64, count uint32) (Status, []byte) {
+	if count == 0 || int64(count) > utils.TimestampMaxCount {
+		return StatusError, []byte(fmt.Sprintf("count must be between 1 and %d", utils.TimestampMaxCount))
+	}
+
+	eh := s.Oracle.Issuer()
+	if err := frontend.CheckLeader(eh, s.advertiseAddrs); err != nil {
+		return errorResponse(err)
+	}
+
+	ctx, cancel := context.WithTimeout(s.conns.Ctx, time.Second)
+	defer cancel()
+	reserved, err := eh.GetUniqueTimestamp(ctx, raft.TimestampRequest{Count: int(count), Priority: raft.PriorityNormal})
+	if err != nil {
+		return errorResponse(fmt.Errorf("error in EpochHost.GetUniqueTimestamp: %w", err))
+	}
+
+	// The rest of the range follows the first timestamp, so the response always fits in a datagram
+	if packing := eh.Packing(); packing != nil {
+		return StatusOK, packing.AppendBytes(nil, reserved.First())
+	}
+	return StatusOK, reserved.First().Bytes()
+}
+
+// errorResponse maps errors to response statuses, clients should retry StatusTryAgain responses later
+func errorResponse(err error) (Status, []byte) {
+	kind, msg := frontend.Reply(err)
+	switch kind {
+	case frontend.KindTryAgain:
+		return StatusTryAgain, []byte(msg)
+	case frontend.KindNotLeader:
+		var notLeader *frontend.NotLeaderError
+		errors.As(err, &notLeader)
+		return StatusNotLeader, []byte(notLeader.Addr)
+	}
+	return StatusError, []byte(msg)
+}
+
+func (s *QUICServer) Shutdown(ctx context.Context) error {
+	// Closing the listener closes every connection
+	return s.conns.Shutdown(ctx)
+}
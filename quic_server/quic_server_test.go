@@ -0,0 +1,83 @@
+package quic_server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatagramRoundTrip(t *testing.T) {
+	id, count, err := decodeRequest(encodeRequest(42, 7))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, uint64(42), id)
+	assert.Equal(t, uint32(7), count)
+
+	_, _, err = decodeRequest(make([]byte, 8))
+	assert.NotNil(t, err)
+
+	id, status, payload, err := decodeResponse(encodeResponse(42, StatusNotLeader, []byte("localhost:8061")))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, uint64(42), id)
+	assert.Equal(t, StatusNotLeader, status)
+	assert.Equal(t, "localhost:8061", string(payload))
+}
+
+func TestExpandTimestamps(t *testing.T) {
+	first := timestamp.Timestamp{Epoch: 1720000000000000000, Index: 41}
+	timestamps, err := expandTimestamps(first.Bytes(), 3)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, (timestamp.Range{Epoch: first.Epoch, StartIndex: 41, Count: 3}).ExpandedBytes(), bytes.Join(timestamps, nil))
+
+	packing := timestamp.Packing{LogicalBits: 18}
+	packed := packing.Pack(timestamp.Timestamp{Epoch: 1720000000000000000, Index: 41})
+	timestamps, err = expandTimestamps(binary.BigEndian.AppendUint64(nil, packed), 3)
+	if !assert.Nil(t, err) {
+		return
+	}
+	for i, ts := range timestamps {
+		assert.Equal(t, timestamp.Timestamp{Epoch: 1720000000000000000, Index: 41 + uint64(i)}, packing.Unpack(binary.BigEndian.Uint64(ts)))
+	}
+
+	_, err = expandTimestamps(make([]byte, 12), 1)
+	assert.NotNil(t, err)
+}
+
+func TestResponseCache(t *testing.T) {
+	c := newResponseCache(2)
+
+	_, isNew := c.begin(1)
+	assert.True(t, isNew)
+	// A retry while in flight is dropped, the response is sent when it's done
+	frame, isNew := c.begin(1)
+	assert.False(t, isNew)
+	assert.Nil(t, frame)
+
+	c.finish(1, []byte("one"), true)
+	frame, isNew = c.begin(1)
+	assert.False(t, isNew)
+	assert.Equal(t, []byte("one"), frame)
+
+	// Responses that aren't cached are handled again when retried
+	c.begin(2)
+	c.finish(2, []byte("two"), false)
+	_, isNew = c.begin(2)
+	assert.True(t, isNew)
+	c.finish(2, []byte("two"), true)
+
+	// The oldest is evicted
+	c.begin(3)
+	_, isNew = c.begin(1)
+	assert.True(t, isNew)
+	frame, isNew = c.begin(3)
+	assert.False(t, isNew)
+	assert.Nil(t, frame)
+}
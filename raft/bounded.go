@@ -0,0 +1,181 @@
+package raft
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+)
+
+type IssueMode string
+
+const (
+	// IssueModeLeader issues unique timestamps from the raft leader only (the default)
+	IssueModeLeader IssueMode = "leader"
+	// IssueModeBounded issues timestamps from every node, with time truncated to the drift interval.
+	// Timestamps from different nodes in the same interval are not ordered, clients must wait out the uncertainty.
+	IssueModeBounded IssueMode = "bounded"
+
+	// nodeBits is how many high bits of the index hold the issuing node ID in the bounded issue mode
+	nodeBits    = 16
+	counterBits = 64 - nodeBits
+	maxCounter  = 1<<counterBits - 1
+)
+
+var ErrInvalidIssueMode = errors.New("ISSUE_MODE must be one of leader or bounded")
+
+type (
+	// boundedIssuer issues timestamps on this node without raft. The epoch is the current time truncated to the
+	// drift interval, and the index is the node ID followed by a counter that resets every interval.
+	boundedIssuer struct {
+		nodeID uint64
+
+		// driftIntervalMicros is the replicated drift interval from the state machine, 0 falls back to DRIFT_INTERVAL_US
+		driftIntervalMicros atomic.Uint64
+		// startedAt is when this node started. The epoch and counter are not persisted, so nothing is issued until
+		// every interval this node could have issued in before a restart (or a clock step back) has passed.
+		startedAt time.Time
+
+		mu      sync.Mutex
+		epoch   uint64
+		counter uint64
+	}
+
+	IssuingConfig struct {
+		Mode                IssueMode `json:"mode"`
+		DriftIntervalMicros uint64    `json:"driftIntervalMicros" validate:"gte=1"`
+		// UncertaintyNs is how long clients must wait out for timestamps from different nodes to be ordered
+		UncertaintyNs time.Duration `json:"uncertaintyNs"`
+		NodeBits      int           `json:"nodeBits"`
+	}
+)
+
+func ParseIssueMode(s string) (IssueMode, error) {
+	switch mode := IssueMode(s); mode {
+	case "", IssueModeLeader:
+		return IssueModeLeader, nil
+	case IssueModeBounded:
+		return mode, nil
+	}
+	return "", ErrInvalidIssueMode
+}
+
+func (b *boundedIssuer) driftInterval() time.Duration {
+	if micros := b.driftIntervalMicros.Load(); micros > 0 {
+		return time.Microsecond * time.Duration(micros)
+	}
+	return time.Microsecond * time.Duration(utils.DriftIntervalMicros)
+}
+
+// truncatedNow is the current time truncated to the drift interval
+func (b *boundedIssuer) truncatedNow() uint64 {
+	interval := uint64(b.driftInterval())
+	now := uint64(time.Now().UnixNano())
+	return now - now%interval
+}
+
+// untilPast is how long from t until time truncated to the drift interval is past epoch
+func (b *boundedIssuer) untilPast(t time.Time, epoch uint64) time.Duration {
+	interval := uint64(b.driftInterval())
+	now := uint64(t.UnixNano())
+	next := max(now-now%interval, epoch-epoch%interval) + interval
+	return time.Duration(next - now)
+}
+
+// readyAt is when this node can start issuing without reissuing timestamps from before it started
+func (b *boundedIssuer) readyAt() time.Time {
+	return b.startedAt.Add(b.driftInterval() + maxClockError)
+}
+
+// uncertainty is how far apart timestamps from different nodes can be while looking the same: anywhere
+// within the drift interval, plus the clock error of both nodes
+func (b *boundedIssuer) uncertainty() time.Duration {
+	return b.driftInterval() + 2*maxClockError
+}
+
+// issue reserves count sequential timestamps from this node, all strictly greater than min
+func (b *boundedIssuer) issue(ctx context.Context, count int, min timestamp.Timestamp) (timestamp.Range, error) {
+	if wait := time.Until(b.readyAt()); wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return timestamp.Range{}, ctx.Err()
+		}
+	}
+
+	for {
+		reserved, waitPast, ok := b.tryIssue(uint64(count), min)
+		if ok {
+			return reserved, nil
+		}
+
+		// Either min is in this interval or later, or this interval ran out of indexes, so wait for a later one
+		select {
+		case <-time.After(b.untilPast(time.Now(), waitPast)):
+		case <-ctx.Done():
+			return timestamp.Range{}, ctx.Err()
+		}
+	}
+}
+
+// tryIssue reserves count timestamps in the current interval, otherwise returning the epoch that the interval must be past first
+func (b *boundedIssuer) tryIssue(count uint64, min timestamp.Timestamp) (timestamp.Range, uint64, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Never go backwards, even if the clock or the drift interval does
+	if epoch := b.truncatedNow(); epoch > b.epoch {
+		b.epoch = epoch
+		b.counter = 0
+	}
+	if b.epoch <= min.Epoch || b.counter+count > maxCounter {
+		return timestamp.Range{}, max(b.epoch, min.Epoch), false
+	}
+
+	start := b.counter + 1
+	b.counter += count
+	return timestamp.Range{
+		Epoch:      b.epoch,
+		StartIndex: b.nodeID<<counterBits | start,
+		Count:      count,
+	}, 0, true
+}
+
+// readTimestamp is at least as large as every timestamp issued so far by any node, as no node's clock
+// is ahead of ours by more than twice the max clock error
+func (b *boundedIssuer) readTimestamp() timestamp.Timestamp {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	interval := uint64(b.driftInterval())
+	latest := uint64(time.Now().Add(2 * maxClockError).UnixNano())
+	return timestamp.Timestamp{Epoch: max(b.epoch, latest-latest%interval), Index: math.MaxUint64}
+}
+
+// safeReadTimestamp is older than any timestamp that can be issued in the future by any node, as no
+// node's clock is behind ours by more than twice the max clock error
+func (b *boundedIssuer) safeReadTimestamp() timestamp.Timestamp {
+	interval := uint64(b.driftInterval())
+	earliest := uint64(time.Now().Add(-2 * maxClockError).UnixNano())
+	return timestamp.Timestamp{Epoch: earliest - earliest%interval}
+}
+
+// waitPast blocks until every node has moved on from the interval of epoch
+func (b *boundedIssuer) waitPast(ctx context.Context, epoch uint64) (uint64, error) {
+	for {
+		safe := b.safeReadTimestamp().Epoch
+		if safe > epoch {
+			return safe, nil
+		}
+
+		select {
+		case <-time.After(b.untilPast(time.Now().Add(-2*maxClockError), epoch)):
+		case <-ctx.Done():
+			return safe, ctx.Err()
+		}
+	}
+}
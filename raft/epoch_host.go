@@ -2,17 +2,43 @@ package raft
 
 import (
 	"context"
-	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/danthegoodman1/EpicEpoch/id"
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
 	"github.com/danthegoodman1/EpicEpoch/utils"
 	"github.com/lni/dragonboat/v3"
+	"golang.org/x/time/rate"
 	"sync/atomic"
 	"time"
 )
 
+var (
+	// ErrOverloaded is returned when the request buffer is saturated and the request was not admitted
+	ErrOverloaded = errors.New("timestamp request buffer is saturated")
+	// ErrQueueBudgetExceeded is returned when a request waited in the request buffer longer than the queue budget
+	ErrQueueBudgetExceeded = errors.New("timestamp request exceeded the queue time budget")
+	// ErrNoAppliedEpoch is returned when the local state machine has not applied an epoch yet
+	ErrNoAppliedEpoch = errors.New("no epoch has been applied yet")
+	// ErrMinTooFarAhead is returned when a min timestamp is further ahead of our clock than MIN_TIMESTAMP_MAX_LEAD_MS
+	ErrMinTooFarAhead = errors.New("min timestamp is too far ahead of the current time")
+	// ErrEpochNotAccepted is returned when newer epochs kept being committed ahead of our proposals
+	ErrEpochNotAccepted = errors.New("proposed epoch was not accepted")
+
+	minTimestampMaxLead = time.Millisecond * time.Duration(utils.MinTimestampMaxLeadMS)
+)
+
 type (
 	EpochHost struct {
 		nodeHost *dragonboat.NodeHost
+		// clusterID is the raft group of this shard
+		clusterID uint64
+		namespace string
+		shard     uint64
+		// indexPrefix is the shard in the high bits of every index when there are multiple shards
+		indexPrefix     uint64
+		indexPrefixBits int
 
 		// The monotonic incrementing index of a single epoch.
 		// Each request must be servied a unique (lastEpoch, epochIndex) value
@@ -22,22 +48,84 @@ type (
 		// used to check whether we need to swap the epoch index
 		lastEpoch atomic.Uint64
 
+		// acceptedEpoch is the newest epoch this node proposed and got accepted. Any other committed epoch
+		// was proposed by another leader, which may have issued timestamps from it.
+		acceptedEpoch atomic.Uint64
+
 		readerAgentStopChan chan struct{}
 
-		requestChan chan *pendingRead
+		// requestQueue holds pending requests of every priority in arrival order, including those that
+		// overflow the request buffer
+		requestQueue *requestQueue
 
 		readerAgentReading atomic.Bool
 
 		// pokeChan is used to poke the reader to generate timestamps
 		pokeChan chan struct{}
 
-		updateTicker *time.Ticker
+		// epochInterval is how often the leader proposes a new epoch
+		epochInterval time.Duration
+		updateTicker  *time.Ticker
+
+		// quota limits how many timestamps are issued for the namespace, shared by all of its shards. nil is unlimited.
+		quota *rate.Limiter
+
+		// batchLingerMicros is how long the reader agent waits for more requests
+		// after being poked before reading from raft, 0 disables lingering
+		batchLingerMicros atomic.Int64
+
+		// batchMaxSize ends the linger window early once this many requests are pending,
+		// 0 always waits out the full window
+		batchMaxSize atomic.Int64
+
+		// queueBudget is how long a request may wait in the request buffer before it is shed
+		// instead of served, 0 disables the budget
+		queueBudget time.Duration
+
+		// appliedEpoch is the latest epoch applied by the local state machine
+		appliedEpoch *epochWatcher
+
+		issueMode IssueMode
+		// bounded issues timestamps from this node in the bounded issue mode
+		bounded *boundedIssuer
+
+		// snowflakes keeps snowflake IDs from different epochs in the same millisecond apart
+		snowflakes *snowflakeGuard
+
+		// packing packs timestamps into a uint64 in the packed layout, nil in the hybrid layout
+		packing *timestamp.Packing
+	}
+
+	BatchingConfig struct {
+		LingerMicros int64 `json:"lingerMicros" validate:"gte=0"`
+		MaxBatchSize int64 `json:"maxBatchSize" validate:"gte=0"`
 	}
 
 	pendingRead struct {
+		// ctx is the context of the caller, if it is done then the caller has abandoned the request
+		ctx context.Context
 		// callbackChan is a channel to write back to with the produced timestamp
-		callbackChan chan []byte
+		callbackChan chan pendingResult
 		count        int
+		priority     Priority
+		min          timestamp.Timestamp
+		// readOnly requests get the high-water mark of issued timestamps rather than reserving unique ones
+		readOnly   bool
+		enqueuedAt time.Time
+	}
+
+	// TimestampRequest is a request for one or more unique timestamps
+	TimestampRequest struct {
+		// Count is how many sequential timestamps to reserve, must be >= 1
+		Count    int
+		Priority Priority
+		// Min, if not zero, guarantees every reserved timestamp is strictly greater than it
+		Min timestamp.Timestamp
+	}
+
+	pendingResult struct {
+		reserved timestamp.Range
+		err      error
 	}
 )
 
@@ -51,28 +139,77 @@ func (e *EpochHost) readerAgentLoop() {
 			return
 		case <-e.pokeChan:
 			logger.Debug().Msg("reader agent poked")
+			e.linger()
 			e.generateTimestamps()
 		}
 	}
 }
 
+// linger waits for more requests to queue up so that they can share a single raft read.
+// It returns once the linger window elapses, or once the max batch size is pending.
+func (e *EpochHost) linger() {
+	window := time.Duration(e.batchLingerMicros.Load()) * time.Microsecond
+	if window <= 0 {
+		return
+	}
+	maxSize := int(e.batchMaxSize.Load())
+
+	s := time.Now()
+	defer func() {
+		metricLingerSeconds.Observe(time.Since(s).Seconds())
+	}()
+
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+	for {
+		if maxSize > 0 && e.requestQueue.Len() >= maxSize {
+			metricLingerExits.WithLabelValues("full").Inc()
+			return
+		}
+		select {
+		case <-timer.C:
+			metricLingerExits.WithLabelValues("timeout").Inc()
+			return
+		case <-e.pokeChan:
+			// A new request arrived, check the batch size again
+		}
+	}
+}
+
+// SetBatching updates the batching behavior of the reader agent, taking effect on the next poke
+func (e *EpochHost) SetBatching(cfg BatchingConfig) {
+	e.batchLingerMicros.Store(cfg.LingerMicros)
+	e.batchMaxSize.Store(cfg.MaxBatchSize)
+	metricLingerMicros.Set(float64(cfg.LingerMicros))
+	metricMaxBatchSize.Set(float64(cfg.MaxBatchSize))
+}
+
+func (e *EpochHost) GetBatching() BatchingConfig {
+	return BatchingConfig{
+		LingerMicros: e.batchLingerMicros.Load(),
+		MaxBatchSize: e.batchMaxSize.Load(),
+	}
+}
+
 // generateTimestamps generates timestamps for pending requests, and handles looping if more requests come in
 func (e *EpochHost) generateTimestamps() {
 	// Capture the current pending requests so there's no case we get locked
-	pendingRequests := len(e.requestChan)
+	pendingRequests := e.requestQueue.Len()
 	logger.Debug().Msgf("Serving %d pending requests", pendingRequests)
+	metricBatchSize.Observe(float64(pendingRequests))
 
 	// Read the epoch
 	s := time.Now()
 	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(raftRttMs)*100)
 	defer cancel()
-	currentEpochI, err := e.nodeHost.SyncRead(ctx, ClusterID, nil)
+	currentEpochI, err := e.nodeHost.SyncRead(ctx, e.clusterID, nil)
 	if err != nil {
 		// This is never good, crash
 		logger.Fatal().Err(err).Msg("error in nodeHost.SyncRead")
 		return
 	}
 	logger.Debug().Msgf("Read from raft in %+v", time.Since(s))
+	metricSyncReadSeconds.Observe(time.Since(s).Seconds())
 
 	currentEpoch, ok := currentEpochI.(PersistenceEpoch)
 	if !ok {
@@ -85,21 +222,21 @@ func (e *EpochHost) generateTimestamps() {
 		// Must be new, write one first
 		currentEpoch.Epoch = uint64(time.Now().UnixNano())
 		logger.Warn().Msgf("read current epoch 0, writing first value %d", currentEpoch.Epoch)
-		err = e.proposeNewEpoch(currentEpoch.Epoch)
+		currentEpoch.Epoch, err = e.proposeNewEpoch(currentEpoch.Epoch)
 		if err != nil {
 			// This is never good, crash
 			logger.Fatal().Err(err).Msg("error in nodeHost.SyncPropose")
 			return
 		}
-	} else if e.lastEpoch.Load() == 0 {
-		// We recently became the leader, we must increment the epoch
-		currentEpoch.Epoch = uint64(time.Now().UnixNano())
-		logger.Warn().Msgf("we must have been elected, incrementing epoch %d", currentEpoch.Epoch)
-		if currentEpoch.Epoch <= e.lastEpoch.Load() {
-			logger.Error().Uint64("newEpoch", currentEpoch.Epoch).Uint64("lastEpoch", e.lastEpoch.Load()).Msg("new epoch less than last epoch, there must be clock drift, incrementing new epoch by 1")
-			currentEpoch.Epoch++
-		}
-		err = e.proposeNewEpoch(currentEpoch.Epoch)
+	} else if currentEpoch.Epoch != e.lastEpoch.Load() && currentEpoch.Epoch != e.acceptedEpoch.Load() {
+		// Another leader committed this epoch, so we must have been elected (or re-elected after losing leadership),
+		// and must move to an epoch past any the previous leader could have issued from
+		previousEpoch := currentEpoch.Epoch
+		newEpoch := max(uint64(time.Now().UnixNano()), previousEpoch+1)
+		logger.Warn().Uint64("previousEpoch", previousEpoch).Msgf("we must have been elected, incrementing epoch %d", newEpoch)
+		// The previous leader may have derived snowflakes from any committed epoch in the same millisecond as ours
+		e.snowflakes.fenceAt(id.Millis(previousEpoch))
+		currentEpoch.Epoch, err = e.proposeNewEpoch(newEpoch)
 		if err != nil {
 			// This is never good, crash
 			logger.Fatal().Err(err).Msg("error in nodeHost.SyncPropose")
@@ -114,60 +251,316 @@ func (e *EpochHost) generateTimestamps() {
 	}
 
 	s = time.Now()
-	for range pendingRequests {
+	skipped := 0
+	reqs, err := e.requestQueue.take(pendingRequests)
+	if err != nil {
+		logger.Warn().Err(err).Msg("request queue disposed, stopping generating timestamps")
+		return
+	}
+	for _, req := range reqs {
 		// Write to the pending requests
-		req := <-e.requestChan
+		if req.ctx.Err() != nil {
+			// The caller gave up waiting, don't burn epoch indexes on it
+			skipped++
+			metricAbandonedRequests.Inc()
+			continue
+		}
+		if e.queueBudget > 0 && s.Sub(req.enqueuedAt) > e.queueBudget {
+			// Waited too long, shed it so the caller can fail fast and retry
+			skipped++
+			metricShedRequests.WithLabelValues("queue_budget", req.priority.String()).Inc()
+			req.callbackChan <- pendingResult{err: ErrQueueBudgetExceeded}
+			continue
+		}
 
-		// Build the timestamp
-		timestamp := make([]byte, 16*req.count) // 8 for epoch, 8 for index, multiply for each
-		logger.Debug().Msgf("writing for %d", req.count)
-		for i := 0; i < req.count; i++ {
-			reqIndex := e.epochIndex.Add(1)
-			offset := i * 16
-			fmt.Println("writing to bounds", offset, offset+16)
-			binary.BigEndian.PutUint64(timestamp[offset:offset+8], currentEpoch.Epoch)
-			binary.BigEndian.PutUint64(timestamp[offset+8:offset+16], reqIndex)
+		if req.readOnly {
+			// Snapshot reads only need a timestamp at least as large as every issued one, so don't consume an index.
+			// Index 0 is never issued, so this is also safe right after the epoch changes.
+			e.respond(req, timestamp.Range{Epoch: currentEpoch.Epoch, StartIndex: e.indexPrefix | e.epochIndex.Load(), Count: 1})
+			continue
 		}
 
-		select {
-		case req.callbackChan <- timestamp:
-		default:
-			logger.Warn().Msg("did not have listener on callback chan when generating timestamp")
+		if req.min != (timestamp.Timestamp{}) {
+			next := timestamp.Timestamp{Epoch: currentEpoch.Epoch, Index: e.indexPrefix | (e.epochIndex.Load() + 1)}
+			if next.Compare(req.min) <= 0 {
+				// The client has seen a timestamp at or beyond what we would serve, move to a later epoch first
+				newEpoch := max(uint64(time.Now().UnixNano()), req.min.Epoch+1)
+				logger.Debug().Uint64("minEpoch", req.min.Epoch).Uint64("newEpoch", newEpoch).Msg("min timestamp at or beyond current epoch, proposing new epoch")
+				metricMinEpochProposals.Inc()
+				currentEpoch.Epoch, err = e.proposeNewEpoch(newEpoch)
+				if err != nil {
+					// This is never good, crash
+					logger.Fatal().Err(err).Msg("error in nodeHost.SyncPropose")
+					return
+				}
+				e.lastEpoch.Store(currentEpoch.Epoch)
+				e.epochIndex.Store(0)
+			}
 		}
+
+		if e.packing != nil && e.epochIndex.Load()+uint64(req.count) > e.packing.MaxLogical() {
+			// The logical bits ran out, move to the next millisecond now rather than waiting for the epoch interval
+			metricLogicalExhaustedProposals.Inc()
+			currentEpoch.Epoch, err = e.proposeNewEpoch(max(uint64(time.Now().UnixNano()), currentEpoch.Epoch+1))
+			if err != nil {
+				// This is never good, crash
+				logger.Fatal().Err(err).Msg("error in nodeHost.SyncPropose")
+				return
+			}
+			e.lastEpoch.Store(currentEpoch.Epoch)
+			e.epochIndex.Store(0)
+		}
+
+		// Reserve the range of indexes, the caller encodes it so we can get to the next request
+		lastIndex := e.epochIndex.Add(uint64(req.count))
+		e.respond(req, timestamp.Range{
+			Epoch:      currentEpoch.Epoch,
+			StartIndex: e.indexPrefix | (lastIndex - uint64(req.count) + 1),
+			Count:      uint64(req.count),
+		})
 	}
-	logger.Debug().Msgf("Served %d requests in %+v", pendingRequests, time.Since(s))
+	logger.Debug().Msgf("Served %d requests (skipped %d abandoned or shed) in %+v", pendingRequests-skipped, skipped, time.Since(s))
 
-	if len(e.requestChan) > 0 {
+	if e.requestQueue.Len() > 0 {
 		// There are more requests, generating more timestamps
 		logger.Debug().Msg("found more requests in request channel, generating more timestamps")
 		e.generateTimestamps()
 	}
 }
 
+// respond hands the reserved timestamps to the waiting caller. The callback chan is buffered so this never
+// blocks the reader agent, a caller that gave up after being checked is detected once the result is sent.
+func (e *EpochHost) respond(req *pendingRead, reserved timestamp.Range) {
+	req.callbackChan <- pendingResult{reserved: reserved}
+	if req.ctx.Err() != nil {
+		metricFailedHandoffs.Inc()
+		logger.Warn().Msg("caller gave up before the generated timestamp was handed back")
+	}
+}
+
+// IssuesLocally is whether this node issues timestamps itself in the bounded issue mode, rather than only the leader
+func (e *EpochHost) IssuesLocally() bool {
+	return e.issueMode == IssueModeBounded
+}
+
+// GetIssuing returns the issue mode and its uncertainty bound. The drift interval is returned in either
+// mode, as it is replicated regardless.
+func (e *EpochHost) GetIssuing() IssuingConfig {
+	cfg := IssuingConfig{
+		Mode:                e.issueMode,
+		DriftIntervalMicros: uint64(e.bounded.driftInterval() / time.Microsecond),
+	}
+	if e.IssuesLocally() {
+		cfg.UncertaintyNs = e.bounded.uncertainty()
+		cfg.NodeBits = nodeBits
+	}
+	return cfg
+}
+
+// SetDriftInterval replicates a new drift interval for the bounded issue mode through raft
+func (e *EpochHost) SetDriftInterval(ctx context.Context, micros uint64) error {
+	session := e.nodeHost.GetNoOPSession(e.clusterID)
+	_, err := e.nodeHost.SyncPropose(ctx, session, utils.MustMarshal(PersistenceEpoch{DriftIntervalMicros: micros}))
+	if err != nil {
+		return fmt.Errorf("error in nodeHost.SyncPropose: %w", err)
+	}
+	return nil
+}
+
+// LeaseClock returns the clock committed along with the epochs of this shard, which lock leases and safepoint
+// registrations expire by. In the bounded issue mode no epochs are proposed, so the current time is committed first.
+func (e *EpochHost) LeaseClock(ctx context.Context) (uint64, error) {
+	state, err := e.readEpoch(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error in readEpoch: %w", err)
+	}
+	if !e.IssuesLocally() && state.Clock > 0 {
+		return state.Clock, nil
+	}
+
+	session := e.nodeHost.GetNoOPSession(e.clusterID)
+	_, err = e.nodeHost.SyncPropose(ctx, session, utils.MustMarshal(PersistenceEpoch{Clock: uint64(time.Now().UnixNano())}))
+	if err != nil {
+		return 0, fmt.Errorf("error in nodeHost.SyncPropose: %w", err)
+	}
+	state, err = e.readEpoch(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error in readEpoch: %w", err)
+	}
+	return state.Clock, nil
+}
+
+// readEpoch reads the committed state of the shard
+func (e *EpochHost) readEpoch(ctx context.Context) (PersistenceEpoch, error) {
+	stateI, err := e.nodeHost.SyncRead(ctx, e.clusterID, nil)
+	if err != nil {
+		return PersistenceEpoch{}, fmt.Errorf("error in nodeHost.SyncRead: %w", err)
+	}
+	return stateI.(PersistenceEpoch), nil
+}
+
 // GetLeader returns the leader node ID of the specified Raft cluster based
 // on local node's knowledge. The returned boolean value indicates whether the
 // leader information is available.
 func (e *EpochHost) GetLeader() (uint64, bool, error) {
-	return e.nodeHost.GetLeaderID(ClusterID)
+	return e.nodeHost.GetLeaderID(e.clusterID)
 }
 
 func (e *EpochHost) Stop() {
 	e.updateTicker.Stop()
 	e.readerAgentStopChan <- struct{}{}
-	e.nodeHost.Stop()
+	e.requestQueue.dispose()
+}
+
+// CommitWait blocks until the committed epoch is past ts, so no timestamp less than or equal to
+// ts can be served again, and returns the committed epoch.
+func (e *EpochHost) CommitWait(ctx context.Context, ts timestamp.Timestamp) (uint64, error) {
+	if e.IssuesLocally() {
+		epoch, err := e.bounded.waitPast(ctx, ts.Epoch)
+		if err != nil {
+			return epoch, fmt.Errorf("error in boundedIssuer.waitPast: %w", err)
+		}
+		return epoch, nil
+	}
+
+	epoch, err := e.appliedEpoch.waitPast(ctx, ts.Epoch)
+	if err != nil {
+		return epoch, fmt.Errorf("error in epochWatcher.waitPast: %w", err)
+	}
+	return epoch, nil
+}
+
+// GetSafeReadTimestamp returns a timestamp from the locally applied epoch that is older than any timestamp
+// that can be issued in the future, along with a bound on how stale it is. It can be served by any node.
+// If readIndex is set, the local state machine is first caught up to the leader with a ReadIndex,
+// trading a round trip to the leader for bounding the staleness to roughly EPOCH_INTERVAL_MS.
+func (e *EpochHost) GetSafeReadTimestamp(ctx context.Context, readIndex bool) (timestamp.Timestamp, time.Duration, error) {
+	if e.IssuesLocally() {
+		// Every node issues from its own clock, so there is nothing to catch up on
+		ts := e.bounded.safeReadTimestamp()
+		return ts, time.Since(time.Unix(0, int64(ts.Epoch))), nil
+	}
+
+	if readIndex {
+		_, err := e.nodeHost.SyncRead(ctx, e.clusterID, nil)
+		if err != nil {
+			return timestamp.Timestamp{}, 0, fmt.Errorf("error in nodeHost.SyncRead: %w", err)
+		}
+	}
+
+	epoch := e.appliedEpoch.get()
+	if epoch == 0 {
+		return timestamp.Timestamp{}, 0, ErrNoAppliedEpoch
+	}
+
+	// Index 0 is never issued, so every future timestamp (in this epoch or later) is greater than this.
+	// The staleness is measured against our clock, so it is only as accurate as clock sync between nodes.
+	staleness := max(time.Since(time.Unix(0, int64(epoch))), 0)
+	return timestamp.Timestamp{Epoch: epoch}, staleness, nil
+}
+
+// GetUniqueTimestamp reserves unique hybrid timestamps to serve to a client, which share
+// the same epoch and have sequential indexes. Under saturation, lower priority requests are shed first.
+func (e *EpochHost) GetUniqueTimestamp(ctx context.Context, req TimestampRequest) (timestamp.Range, error) {
+	if req.Count < 1 {
+		return timestamp.Range{}, fmt.Errorf("count must be >= 1")
+	}
+	if e.packing != nil && uint64(req.Count) > e.packing.MaxLogical() {
+		return timestamp.Range{}, ErrCountExceedsLogical
+	}
+	if maxEpoch := uint64(time.Now().Add(minTimestampMaxLead).UnixNano()); req.Min.Epoch > maxEpoch {
+		// Otherwise a client could push the epoch arbitrarily far into the future
+		return timestamp.Range{}, ErrMinTooFarAhead
+	}
+	if err := e.takeQuota(req.Count, req.Priority); err != nil {
+		return timestamp.Range{}, err
+	}
+	if e.IssuesLocally() {
+		reserved, err := e.bounded.issue(ctx, req.Count, req.Min)
+		if err != nil {
+			return timestamp.Range{}, fmt.Errorf("error in boundedIssuer.issue: %w", err)
+		}
+		return reserved, nil
+	}
+	pr := &pendingRead{
+		ctx:          ctx,
+		callbackChan: make(chan pendingResult, 1),
+		count:        req.Count,
+		priority:     req.Priority,
+		min:          req.Min,
+		enqueuedAt:   time.Now(),
+	}
+
+	return e.submit(pr)
+}
+
+// GetReadTimestamp returns a timestamp at least as large as every timestamp issued so far, for snapshot reads.
+// It is not unique, but is linearizable as it shares the same raft read as unique timestamp requests.
+func (e *EpochHost) GetReadTimestamp(ctx context.Context, priority Priority) (timestamp.Timestamp, error) {
+	if err := e.takeQuota(1, priority); err != nil {
+		return timestamp.Timestamp{}, err
+	}
+	if e.IssuesLocally() {
+		return e.bounded.readTimestamp(), nil
+	}
+
+	pr := &pendingRead{
+		ctx:          ctx,
+		callbackChan: make(chan pendingResult, 1),
+		count:        1,
+		priority:     priority,
+		readOnly:     true,
+		enqueuedAt:   time.Now(),
+	}
+
+	reserved, err := e.submit(pr)
+	if err != nil {
+		return timestamp.Timestamp{}, err
+	}
+
+	return reserved.First(), nil
 }
 
-// GetUniqueTimestamp gets a unique hybrid timestamp to serve to a client
-func (e *EpochHost) GetUniqueTimestamp(ctx context.Context, count int) ([]byte, error) {
-	if count < 1 {
-		return nil, fmt.Errorf("count must be >= 1")
+// takeQuota takes count timestamps from the namespace quota, or returns ErrQuotaExceeded if there aren't enough left
+func (e *EpochHost) takeQuota(count int, priority Priority) error {
+	if e.quota == nil {
+		return nil
+	}
+	if burst := e.quota.Burst(); count > burst {
+		// There will never be enough, so don't have the caller retry
+		return fmt.Errorf("%w (%d)", ErrCountExceedsQuota, burst)
 	}
-	pr := &pendingRead{callbackChan: make(chan []byte, 1), count: count}
+	if e.quota.AllowN(time.Now(), count) {
+		return nil
+	}
+	metricShedRequests.WithLabelValues("quota", priority.String()).Inc()
+	return ErrQuotaExceeded
+}
 
-	// Register request
-	err := utils.WriteWithContext(ctx, e.requestChan, pr)
+// QuotaBurst is the most timestamps a single request can take from the namespace quota, 0 if it is unlimited
+func (e *EpochHost) QuotaBurst() int {
+	if e.quota == nil {
+		return 0
+	}
+	return e.quota.Burst()
+}
+
+// Namespace is the name of the namespace this shard belongs to
+func (e *EpochHost) Namespace() string {
+	return e.namespace
+}
+
+// submit enqueues a request for the reader agent, and waits for it to be served
+func (e *EpochHost) submit(pr *pendingRead) (timestamp.Range, error) {
+	// Register request, shedding it immediately if the buffer is saturated rather than
+	// having the caller block until it times out
+	ok, err := e.requestQueue.offer(pr)
 	if err != nil {
-		return nil, fmt.Errorf("error writing nil, pending request to request buffer: %w", err)
+		return timestamp.Range{}, fmt.Errorf("error in requestQueue.offer: %w", err)
+	}
+	if !ok {
+		metricShedRequests.WithLabelValues("queue_full", pr.priority.String()).Inc()
+		return timestamp.Range{}, ErrOverloaded
 	}
 
 	// Try to poke the reader goroutine
@@ -179,30 +572,76 @@ func (e *EpochHost) GetUniqueTimestamp(ctx context.Context, count int) ([]byte,
 	}
 
 	// Wait for the response
-	timestamp, err := utils.ReadWithContext(ctx, pr.callbackChan)
+	res, err := utils.ReadWithContext(pr.ctx, pr.callbackChan)
 	if err != nil {
-		return nil, fmt.Errorf("error reading from callback channel with context: %w", err)
+		return timestamp.Range{}, fmt.Errorf("error reading from callback channel with context: %w", err)
+	}
+	if res.err != nil {
+		return timestamp.Range{}, res.err
 	}
 
-	return timestamp, nil
+	return res.reserved, nil
 }
 
-func (e *EpochHost) proposeNewEpoch(newEpoch uint64) error {
-	session := e.nodeHost.GetNoOPSession(ClusterID)
-	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(raftRttMs)*200)
-	defer cancel()
-	_, err := e.nodeHost.SyncPropose(ctx, session, utils.MustMarshal(PersistenceEpoch{Epoch: newEpoch}))
-	if err != nil {
-		return fmt.Errorf("error in nodeHost.SyncPropose: %w", err)
+// QueueDepth returns the number of requests waiting, and the capacity of the request and overflow buffers combined
+func (e *EpochHost) QueueDepth() (depth, capacity int) {
+	return e.requestQueue.Len(), e.requestQueue.Capacity()
+}
+
+// maxEpochProposalAttempts is how many times proposeNewEpoch proposes past newer committed epochs before giving up
+const maxEpochProposalAttempts = 10
+
+// proposeNewEpoch proposes a new epoch, and returns the epoch that was committed as a result.
+// If a newer epoch was already committed the proposal is rejected. Another node (e.g. a previous leader) may have
+// issued timestamps from that epoch, so it proposes again past it, and only ever returns an epoch it got accepted.
+func (e *EpochHost) proposeNewEpoch(newEpoch uint64) (uint64, error) {
+	session := e.nodeHost.GetNoOPSession(e.clusterID)
+	for range maxEpochProposalAttempts {
+		newEpoch = e.wholeMillis(newEpoch)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(raftRttMs)*200)
+		clock := min(newEpoch, uint64(time.Now().UnixNano()))
+		res, err := e.nodeHost.SyncPropose(ctx, session, utils.MustMarshal(PersistenceEpoch{Epoch: newEpoch, Clock: clock}))
+		cancel()
+		if err != nil {
+			return 0, fmt.Errorf("error in nodeHost.SyncPropose: %w", err)
+		}
+
+		if res.Value == EpochAccepted {
+			e.setAcceptedEpoch(newEpoch)
+			return newEpoch, nil
+		}
+
+		var current PersistenceEpoch
+		err = json.Unmarshal(res.Data, &current)
+		if err != nil {
+			return 0, fmt.Errorf("error in json.Unmarshal: %w", err)
+		}
+		logger.Warn().Uint64("newEpoch", newEpoch).Uint64("currentEpoch", current.Epoch).Msg("proposed epoch was rejected, proposing past the current epoch")
+		newEpoch = max(uint64(time.Now().UnixNano()), current.Epoch+1)
 	}
 
-	return nil
+	return 0, ErrEpochNotAccepted
+}
+
+// setAcceptedEpoch records an epoch this node got accepted, the epoch ticker and reader agent propose concurrently
+// so it only moves forward
+func (e *EpochHost) setAcceptedEpoch(epoch uint64) {
+	for {
+		accepted := e.acceptedEpoch.Load()
+		if epoch <= accepted || e.acceptedEpoch.CompareAndSwap(accepted, epoch) {
+			return
+		}
+	}
 }
 
 type (
 	Membership struct {
 		Leader  Member   `json:"leader"`
 		Members []Member `json:"members"`
+		// ShardLeaders is the leader of every shard, in order, when there are multiple shards
+		ShardLeaders []Member `json:"shardLeaders,omitempty"`
+		// MetaLeader is the leader of the meta raft group, which serves sequences
+		MetaLeader *Member `json:"metaLeader,omitempty"`
 	}
 
 	Member struct {
@@ -212,7 +651,7 @@ type (
 )
 
 func (e *EpochHost) GetMembership(ctx context.Context) (*Membership, error) {
-	leader, available, err := e.nodeHost.GetLeaderID(ClusterID)
+	leader, available, err := e.nodeHost.GetLeaderID(e.clusterID)
 	if err != nil {
 		return nil, fmt.Errorf("error getting membership: %e", err)
 	}
@@ -221,7 +660,7 @@ func (e *EpochHost) GetMembership(ctx context.Context) (*Membership, error) {
 		return nil, fmt.Errorf("raft membership not avilable")
 	}
 
-	membership, err := e.nodeHost.SyncGetClusterMembership(ctx, ClusterID)
+	membership, err := e.nodeHost.SyncGetClusterMembership(ctx, e.clusterID)
 	if err != nil {
 		return nil, fmt.Errorf("error in nodeHost.SyncGetClusterMembership: %w", err)
 	}
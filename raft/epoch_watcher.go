@@ -0,0 +1,55 @@
+package raft
+
+import (
+	"context"
+	"sync"
+)
+
+// epochWatcher tracks the latest epoch applied by the local state machine, and lets
+// callers block until it passes a given epoch
+type epochWatcher struct {
+	mu    sync.Mutex
+	epoch uint64
+	// changed is closed and replaced every time the epoch advances, waking all waiters
+	changed chan struct{}
+}
+
+func newEpochWatcher() *epochWatcher {
+	return &epochWatcher{changed: make(chan struct{})}
+}
+
+// set is called by the state machine whenever it applies an epoch
+func (w *epochWatcher) set(epoch uint64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if epoch <= w.epoch {
+		return
+	}
+	w.epoch = epoch
+	close(w.changed)
+	w.changed = make(chan struct{})
+}
+
+func (w *epochWatcher) get() uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.epoch
+}
+
+// waitPast blocks until the applied epoch is greater than epoch, returning the applied epoch
+func (w *epochWatcher) waitPast(ctx context.Context, epoch uint64) (uint64, error) {
+	for {
+		w.mu.Lock()
+		current, changed := w.epoch, w.changed
+		w.mu.Unlock()
+		if current > epoch {
+			return current, nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return current, ctx.Err()
+		}
+	}
+}
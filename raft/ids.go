@@ -0,0 +1,142 @@
+package raft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/danthegoodman1/EpicEpoch/id"
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+)
+
+const (
+	// idAttempts is how many times IDs are issued before giving up, as each attempt that doesn't fit moves to a later epoch
+	idAttempts = 3
+	// snowflakeGuardSize is how many milliseconds the snowflake guard remembers before forgetting the oldest
+	snowflakeGuardSize = 1024
+)
+
+var (
+	// ErrInvalidIDCount is returned when more IDs are requested than fit in a single epoch
+	ErrInvalidIDCount = errors.New("id count does not fit in a single epoch")
+	// ErrIDNamespace is returned when requesting IDs from a namespace other than the default one. Namespaces issue
+	// from independent epochs, and there is no room for them in the ID bits, so their IDs would collide.
+	ErrIDNamespace = errors.New("ids are only issued from the default namespace")
+
+	SnowflakeLayout = id.SnowflakeLayout{
+		EpochMs:   uint64(utils.SnowflakeEpochMS),
+		TimeBits:  int(utils.SnowflakeTimeBits),
+		IndexBits: int(utils.SnowflakeIndexBits),
+	}
+)
+
+// snowflakeGuard makes sure every millisecond that snowflakes are derived from is only used by a single epoch,
+// as snowflakes drop the rest of the epoch and the index starts over in every epoch
+type snowflakeGuard struct {
+	mu sync.Mutex
+	// fence is the latest millisecond that may have been used by an epoch this node doesn't know about,
+	// either from a previous leader or because it was forgotten
+	fence uint64
+	// used is the epoch that each millisecond after the fence was used by
+	used map[uint64]uint64
+}
+
+func newSnowflakeGuard() *snowflakeGuard {
+	return &snowflakeGuard{used: map[uint64]uint64{}}
+}
+
+// fenceAt marks every millisecond up to and including millis as unsafe, unless its epoch was already used by this node
+func (g *snowflakeGuard) fenceAt(millis uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.fence = max(g.fence, millis)
+}
+
+// use claims the millisecond of epoch for it, returning the latest unsafe millisecond if it can't be
+func (g *snowflakeGuard) use(epoch uint64) (uint64, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	millis := id.Millis(epoch)
+	if used, exists := g.used[millis]; exists {
+		return millis, used == epoch
+	}
+	if millis <= g.fence {
+		return g.fence, false
+	}
+
+	if len(g.used) >= snowflakeGuardSize {
+		// Forget the older half, fencing them so a late epoch can't reuse them
+		var newest uint64
+		for m := range g.used {
+			newest = max(newest, m)
+		}
+		for m := range g.used {
+			if m < newest-snowflakeGuardSize/2 {
+				g.fence = max(g.fence, m)
+				delete(g.used, m)
+			}
+		}
+		if millis <= g.fence {
+			return g.fence, false
+		}
+	}
+
+	g.used[millis] = epoch
+	return millis, true
+}
+
+// idEncoder encodes IDs of a format from the timestamps of this shard
+func (e *EpochHost) idEncoder(format id.Format) id.Encoder {
+	prefixBits := e.indexPrefixBits
+	if e.IssuesLocally() {
+		prefixBits = nodeBits
+	}
+	return id.Encoder{Format: format, PrefixBits: prefixBits, Snowflake: SnowflakeLayout}
+}
+
+// GetIDs issues count unique timestamps and derives IDs of the format from them. Timestamps that don't fit in
+// the format (an index too large, or a snowflake millisecond already used by another epoch) are dropped,
+// and new ones are issued from a later epoch.
+func (e *EpochHost) GetIDs(ctx context.Context, format id.Format, count int, priority Priority) ([]string, error) {
+	if e.namespace != DefaultNamespace {
+		return nil, ErrIDNamespace
+	}
+	enc := e.idEncoder(format)
+	if count < 1 || uint64(count) > enc.MaxCount() {
+		return nil, fmt.Errorf("%w, must be between 1 and %d", ErrInvalidIDCount, enc.MaxCount())
+	}
+
+	req := TimestampRequest{Count: count, Priority: priority}
+	for attempt := 0; attempt < idAttempts; attempt++ {
+		reserved, err := e.GetUniqueTimestamp(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("error in GetUniqueTimestamp: %w", err)
+		}
+
+		if format == id.FormatSnowflake {
+			if unsafe, ok := e.snowflakes.use(reserved.Epoch); !ok {
+				// Issue again from an epoch in a later millisecond
+				metricIDRetries.WithLabelValues(string(format)).Inc()
+				req.Min = timestamp.Timestamp{Epoch: (unsafe+1)*1e6 - 1, Index: math.MaxUint64}
+				continue
+			}
+		}
+
+		ids, err := enc.EncodeRange(reserved)
+		if errors.Is(err, id.ErrDoesNotFit) {
+			// The index ran past what the format has room for, issue again from the next epoch
+			metricIDRetries.WithLabelValues(string(format)).Inc()
+			req.Min = timestamp.Timestamp{Epoch: reserved.Epoch, Index: math.MaxUint64}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error in Encoder.EncodeRange: %w", err)
+		}
+		return ids, nil
+	}
+
+	return nil, fmt.Errorf("no timestamps fit after %d attempts: %w", idAttempts, id.ErrDoesNotFit)
+}
@@ -0,0 +1,173 @@
+package raft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+)
+
+type LockOp string
+
+const (
+	LockOpAcquire LockOp = "acquire"
+	LockOpRenew   LockOp = "renew"
+	LockOpRelease LockOp = "release"
+)
+
+var (
+	ErrInvalidLock = errors.New("lock name and owner must not be empty")
+	// ErrLockHeld is returned when acquiring a lock that another owner holds an unexpired lease of
+	ErrLockHeld = errors.New("lock is held by another owner")
+	// ErrLockLost is returned when renewing or releasing a lease that expired, or was never held with the given token
+	ErrLockLost = errors.New("lock lease is not held with this owner and token")
+)
+
+type (
+	// Lock is the lease of a named lock
+	Lock struct {
+		Owner string `json:"owner"`
+		// Token is the fencing token of the lease, an issued hybrid timestamp that is greater than the
+		// token of every earlier lease of the lock
+		Token timestamp.Timestamp `json:"token"`
+		// ExpiresAt is the time (unix nanoseconds) by the lease clock at which the lease expires
+		ExpiresAt uint64 `json:"expiresAt,string"`
+		// Held is whether the lease was unexpired by the lease clock when it was returned, it is always false in the state machine
+		Held bool `json:"held"`
+	}
+
+	// LockCommand changes a lock in the meta state machine
+	LockCommand struct {
+		Op    LockOp
+		Name  string
+		Owner string
+		Token timestamp.Timestamp
+		// Now is the issuer's lease clock when the command was proposed, which a new lease expires TTL after
+		Now uint64
+		TTL time.Duration
+	}
+)
+
+// applyLock applies a lock command, returning whether it was accepted along with the resulting lease of the lock
+func (p *PersistenceMeta) applyLock(cmd LockCommand) (uint64, Lock) {
+	p.LeaseClock = max(p.LeaseClock, cmd.Now)
+	lock, exists := p.Locks[cmd.Name]
+	held := exists && lock.ExpiresAt > p.LeaseClock
+
+	switch cmd.Op {
+	case LockOpAcquire:
+		if held && lock.Owner != cmd.Owner {
+			return MetaRejected, lock
+		}
+		if cmd.Token.Compare(lock.Token) <= 0 {
+			// A proposal that was overtaken by a later acquire, fencing tokens must never go backwards
+			return MetaRejected, lock
+		}
+		lock = Lock{Owner: cmd.Owner, Token: cmd.Token, ExpiresAt: p.LeaseClock + uint64(cmd.TTL)}
+	case LockOpRenew:
+		if !held || lock.Owner != cmd.Owner || lock.Token != cmd.Token {
+			return MetaRejected, lock
+		}
+		lock.ExpiresAt = max(lock.ExpiresAt, p.LeaseClock+uint64(cmd.TTL))
+	case LockOpRelease:
+		if !held || lock.Owner != cmd.Owner || lock.Token != cmd.Token {
+			return MetaRejected, lock
+		}
+		// Keep the token so the next lease's token is still checked against it
+		lock.ExpiresAt = 0
+	default:
+		return MetaRejected, lock
+	}
+
+	p.Locks[cmd.Name] = lock
+	return MetaAccepted, lock
+}
+
+// Issuer is the shard that issues fencing tokens, and whose lease clock drives the expiries of lock leases
+// and safepoint registrations. Requests that change them must be sent to its leader.
+func (o *Oracle) Issuer() *EpochHost {
+	return o.namespaces[DefaultNamespace].shards[0]
+}
+
+// AcquireLock acquires a lease of a named lock for ttl, or renews it with a new fencing token if owner already holds it
+func (o *Oracle) AcquireLock(ctx context.Context, name, owner string, ttl time.Duration) (Lock, error) {
+	if name == "" || owner == "" {
+		return Lock{}, ErrInvalidLock
+	}
+
+	// The fencing token must be greater than the token of every earlier lease. Issuing it after the latest
+	// one keeps that true across leadership changes, and in the bounded issue mode where nodes' timestamps
+	// are not ordered.
+	latest, err := o.GetLock(ctx, name)
+	if err != nil {
+		return Lock{}, fmt.Errorf("error in Oracle.GetLock: %w", err)
+	}
+	reserved, err := o.Issuer().GetUniqueTimestamp(ctx, TimestampRequest{Count: 1, Priority: PriorityHigh, Min: latest.Token})
+	if err != nil {
+		return Lock{}, fmt.Errorf("error in EpochHost.GetUniqueTimestamp: %w", err)
+	}
+
+	return o.proposeLock(ctx, LockCommand{Op: LockOpAcquire, Name: name, Owner: owner, Token: reserved.First(), TTL: ttl}, ErrLockHeld)
+}
+
+// RenewLock extends a held lease to ttl from now
+func (o *Oracle) RenewLock(ctx context.Context, name, owner string, token timestamp.Timestamp, ttl time.Duration) (Lock, error) {
+	if name == "" || owner == "" {
+		return Lock{}, ErrInvalidLock
+	}
+
+	return o.proposeLock(ctx, LockCommand{Op: LockOpRenew, Name: name, Owner: owner, Token: token, TTL: ttl}, ErrLockLost)
+}
+
+// ReleaseLock releases a held lease, so the lock can be acquired by another owner
+func (o *Oracle) ReleaseLock(ctx context.Context, name, owner string, token timestamp.Timestamp) error {
+	if name == "" || owner == "" {
+		return ErrInvalidLock
+	}
+
+	_, err := o.proposeLock(ctx, LockCommand{Op: LockOpRelease, Name: name, Owner: owner, Token: token}, ErrLockLost)
+	return err
+}
+
+// GetLock returns the latest lease of a lock, and whether it is held as of the lease clock
+func (o *Oracle) GetLock(ctx context.Context, name string) (Lock, error) {
+	now, err := o.Issuer().LeaseClock(ctx)
+	if err != nil {
+		return Lock{}, fmt.Errorf("error in EpochHost.LeaseClock: %w", err)
+	}
+	lockI, err := o.meta.nodeHost.SyncRead(ctx, o.meta.clusterID, lockLookup{Name: name, Now: now})
+	if err != nil {
+		return Lock{}, fmt.Errorf("error in nodeHost.SyncRead: %w", err)
+	}
+
+	return lockI.(Lock), nil
+}
+
+// proposeLock proposes a lock command to the meta raft group with the lease clock, returning rejectedErr if it was rejected
+func (o *Oracle) proposeLock(ctx context.Context, cmd LockCommand, rejectedErr error) (Lock, error) {
+	var err error
+	cmd.Now, err = o.Issuer().LeaseClock(ctx)
+	if err != nil {
+		return Lock{}, fmt.Errorf("error in EpochHost.LeaseClock: %w", err)
+	}
+	session := o.meta.nodeHost.GetNoOPSession(o.meta.clusterID)
+	res, err := o.meta.nodeHost.SyncPropose(ctx, session, utils.MustMarshal(MetaCommand{Lock: &cmd}))
+	if err != nil {
+		return Lock{}, fmt.Errorf("error in nodeHost.SyncPropose: %w", err)
+	}
+
+	var lock Lock
+	err = json.Unmarshal(res.Data, &lock)
+	if err != nil {
+		return Lock{}, fmt.Errorf("error in json.Unmarshal: %w", err)
+	}
+	if res.Value == MetaRejected {
+		return lock, rejectedErr
+	}
+
+	return lock, nil
+}
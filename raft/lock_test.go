@@ -0,0 +1,146 @@
+package raft
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyLock(t *testing.T) {
+	const now = uint64(1720000000000000000)
+	ttl := 10 * time.Second
+	token := timestamp.Timestamp{Epoch: now, Index: 5}
+	later := timestamp.Timestamp{Epoch: now, Index: 6}
+	held := Lock{Owner: "a", Token: token, ExpiresAt: now + uint64(ttl)}
+
+	tests := []struct {
+		name       string
+		lock       *Lock
+		leaseClock uint64
+		cmd        LockCommand
+		accepted   bool
+		want       Lock
+	}{
+		{
+			name:     "acquire free lock",
+			cmd:      LockCommand{Op: LockOpAcquire, Name: "l", Owner: "a", Token: token, Now: now, TTL: ttl},
+			accepted: true,
+			want:     held,
+		},
+		{
+			name:     "acquire lock held by another owner",
+			lock:     &held,
+			cmd:      LockCommand{Op: LockOpAcquire, Name: "l", Owner: "b", Token: later, Now: now + 1, TTL: ttl},
+			accepted: false,
+			want:     held,
+		},
+		{
+			name:     "acquire expired lock",
+			lock:     &held,
+			cmd:      LockCommand{Op: LockOpAcquire, Name: "l", Owner: "b", Token: later, Now: held.ExpiresAt, TTL: ttl},
+			accepted: true,
+			want:     Lock{Owner: "b", Token: later, ExpiresAt: held.ExpiresAt + uint64(ttl)},
+		},
+		{
+			name:     "acquire held lock again by its owner",
+			lock:     &held,
+			cmd:      LockCommand{Op: LockOpAcquire, Name: "l", Owner: "a", Token: later, Now: now + 1, TTL: ttl},
+			accepted: true,
+			want:     Lock{Owner: "a", Token: later, ExpiresAt: now + 1 + uint64(ttl)},
+		},
+		{
+			name:     "acquire with a token that is not greater than the last lease's",
+			lock:     &Lock{Owner: "a", Token: later},
+			cmd:      LockCommand{Op: LockOpAcquire, Name: "l", Owner: "b", Token: token, Now: now, TTL: ttl},
+			accepted: false,
+			want:     Lock{Owner: "a", Token: later},
+		},
+		{
+			name: "token ahead of the clock does not move expiries",
+			lock: &held,
+			// A client's min timestamp can push the committed epoch, and so tokens, well ahead of the clock
+			cmd:      LockCommand{Op: LockOpAcquire, Name: "l", Owner: "b", Token: timestamp.Timestamp{Epoch: now + uint64(time.Minute)}, Now: now + 1, TTL: ttl},
+			accepted: false,
+			want:     held,
+		},
+		{
+			name:     "renew held lock",
+			lock:     &held,
+			cmd:      LockCommand{Op: LockOpRenew, Name: "l", Owner: "a", Token: token, Now: now + 1, TTL: ttl},
+			accepted: true,
+			want:     Lock{Owner: "a", Token: token, ExpiresAt: now + 1 + uint64(ttl)},
+		},
+		{
+			name:     "renew never shortens the lease",
+			lock:     &held,
+			cmd:      LockCommand{Op: LockOpRenew, Name: "l", Owner: "a", Token: token, Now: now + 1, TTL: time.Second},
+			accepted: true,
+			want:     held,
+		},
+		{
+			name:     "renew with another token",
+			lock:     &held,
+			cmd:      LockCommand{Op: LockOpRenew, Name: "l", Owner: "a", Token: later, Now: now + 1, TTL: ttl},
+			accepted: false,
+			want:     held,
+		},
+		{
+			name:     "renew expired lock",
+			lock:     &held,
+			cmd:      LockCommand{Op: LockOpRenew, Name: "l", Owner: "a", Token: token, Now: held.ExpiresAt, TTL: ttl},
+			accepted: false,
+			want:     held,
+		},
+		{
+			name:       "renew after the lease clock passed the expiry, with an older clock",
+			lock:       &held,
+			leaseClock: held.ExpiresAt,
+			cmd:        LockCommand{Op: LockOpRenew, Name: "l", Owner: "a", Token: token, Now: now + 1, TTL: ttl},
+			accepted:   false,
+			want:       held,
+		},
+		{
+			name:     "release held lock keeps its token",
+			lock:     &held,
+			cmd:      LockCommand{Op: LockOpRelease, Name: "l", Owner: "a", Token: token, Now: now + 1},
+			accepted: true,
+			want:     Lock{Owner: "a", Token: token},
+		},
+		{
+			name:     "release by another owner",
+			lock:     &held,
+			cmd:      LockCommand{Op: LockOpRelease, Name: "l", Owner: "b", Token: token, Now: now + 1},
+			accepted: false,
+			want:     held,
+		},
+		{
+			name:     "unknown op",
+			lock:     &held,
+			cmd:      LockCommand{Op: "steal", Name: "l", Owner: "b", Token: later, Now: now + 1, TTL: ttl},
+			accepted: false,
+			want:     held,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PersistenceMeta{LeaseClock: tt.leaseClock}
+			p.init()
+			if tt.lock != nil {
+				p.Locks["l"] = *tt.lock
+			}
+
+			result, lock := p.applyLock(tt.cmd)
+			if tt.accepted {
+				assert.Equal(t, MetaAccepted, result)
+			} else {
+				assert.Equal(t, MetaRejected, result)
+			}
+			assert.Equal(t, tt.want, lock)
+			assert.Equal(t, tt.want, p.Locks["l"])
+			assert.Equal(t, max(tt.leaseClock, tt.cmd.Now), p.LeaseClock)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package raft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+	"github.com/lni/dragonboat/v3"
+	"github.com/lni/dragonboat/v3/config"
+	"github.com/lni/dragonboat/v3/statemachine"
+	"sync"
+	"time"
+)
+
+var ErrInvalidSequence = errors.New("sequence name must not be empty")
+
+type (
+	// MetaHost serves everything in the meta state machine, from the leader of the meta raft group
+	MetaHost struct {
+		nodeHost  *dragonboat.NodeHost
+		clusterID uint64
+
+		// sequenceBlockSize is how many values of a sequence are leased from raft at a time
+		sequenceBlockSize uint64
+		sequencesMu       sync.Mutex
+		// sequences are the blocks leased by this node, by sequence name
+		sequences map[string]*sequenceBlock
+	}
+
+	// sequenceBlock is a range of sequence values leased from raft that haven't been handed out yet
+	sequenceBlock struct {
+		// next is the next value to hand out
+		next uint64
+		// end is the last value of the block, and the last value leased when we leased it
+		end uint64
+	}
+
+	// SequenceRange is count sequential values of a sequence, starting at Start
+	SequenceRange struct {
+		Start uint64 `json:"start,string"`
+		Count uint64 `json:"count"`
+	}
+)
+
+// startMetaHost starts the meta raft group
+func startMetaHost(nh *dragonboat.NodeHost) (*MetaHost, error) {
+	rc := config.Config{
+		NodeID:             utils.NodeID,
+		ClusterID:          MetaClusterID,
+		ElectionRTT:        10,
+		HeartbeatRTT:       1,
+		CheckQuorum:        true,
+		SnapshotEntries:    100,
+		CompactionOverhead: 50,
+	}
+	err := nh.StartOnDiskCluster(map[uint64]dragonboat.Target{
+		1: "localhost:60001",
+		2: "localhost:60002",
+		3: "localhost:60003",
+	}, false, func(clusterID, nodeID uint64) statemachine.IOnDiskStateMachine {
+		return NewMetaStateMachine(clusterID, nodeID)
+	}, rc)
+	if err != nil {
+		return nil, fmt.Errorf("error in StartOnDiskCluster: %w", err)
+	}
+
+	return &MetaHost{
+		nodeHost:          nh,
+		clusterID:         MetaClusterID,
+		sequenceBlockSize: uint64(max(utils.SequenceBlockSize, 1)),
+		sequences:         map[string]*sequenceBlock{},
+	}, nil
+}
+
+// GetLeader returns the leader node ID of the meta raft group based on local node's knowledge
+func (m *MetaHost) GetLeader() (uint64, bool, error) {
+	return m.nodeHost.GetLeaderID(m.clusterID)
+}
+
+// NextSequence hands out count sequential values of a named sequence, which are strictly greater than every
+// value handed out before. Values are served from a block leased from raft, so only exhausting a block
+// needs a proposal. Blocks that are lost (e.g. on a leadership change) leave gaps in the sequence.
+func (m *MetaHost) NextSequence(ctx context.Context, name string, count uint64) (SequenceRange, error) {
+	if name == "" {
+		return SequenceRange{}, ErrInvalidSequence
+	}
+	if count < 1 {
+		return SequenceRange{}, fmt.Errorf("count must be >= 1")
+	}
+
+	// The linearizable read tells us if another node leased a block after ours, in which case its values
+	// are greater than what is left in our block
+	leasedI, err := m.nodeHost.SyncRead(ctx, m.clusterID, sequenceLookup(name))
+	if err != nil {
+		return SequenceRange{}, fmt.Errorf("error in nodeHost.SyncRead: %w", err)
+	}
+	leased := leasedI.(uint64)
+
+	m.sequencesMu.Lock()
+	defer m.sequencesMu.Unlock()
+	return m.takeSequence(name, count, leased, func(incr uint64) (uint64, error) {
+		return m.leaseSequence(ctx, name, incr)
+	})
+}
+
+// takeSequence hands out count values from our block of a sequence, given the last value leased by any node.
+// If the block was passed by another node's lease, or doesn't have count values left, a new one is leased with lease.
+// m.sequencesMu must be held.
+func (m *MetaHost) takeSequence(name string, count, leased uint64, lease func(incr uint64) (uint64, error)) (SequenceRange, error) {
+	block := m.sequences[name]
+	if block != nil && leased > block.end {
+		metricSequenceBlocksDropped.Inc()
+		block = nil
+	}
+	if block == nil || block.end-block.next+1 < count {
+		incr := max(m.sequenceBlockSize, count)
+		end, err := lease(incr)
+		if err != nil {
+			return SequenceRange{}, fmt.Errorf("error in leaseSequence: %w", err)
+		}
+		if block != nil && end == block.end+incr {
+			// Nothing was leased in between, so extend our block
+			block.end = end
+		} else {
+			block = &sequenceBlock{next: end - incr + 1, end: end}
+		}
+		m.sequences[name] = block
+	}
+
+	start := block.next
+	block.next += count
+	return SequenceRange{Start: start, Count: count}, nil
+}
+
+// leaseSequence leases incr values of a sequence through raft, returning the last leased value
+func (m *MetaHost) leaseSequence(ctx context.Context, name string, incr uint64) (uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Millisecond*time.Duration(raftRttMs)*200)
+	defer cancel()
+	session := m.nodeHost.GetNoOPSession(m.clusterID)
+	res, err := m.nodeHost.SyncPropose(ctx, session, utils.MustMarshal(MetaCommand{Sequence: name, Incr: incr}))
+	if err != nil {
+		return 0, fmt.Errorf("error in nodeHost.SyncPropose: %w", err)
+	}
+	metricSequenceLeases.Inc()
+
+	return res.Value, nil
+}
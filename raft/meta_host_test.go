@@ -0,0 +1,123 @@
+package raft
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeSequence(t *testing.T) {
+	const blockSize = 10
+
+	tests := []struct {
+		name   string
+		block  *sequenceBlock
+		leased uint64
+		// leasedBetween is how many values another node leases after we read leased, but before our lease
+		leasedBetween uint64
+		count         uint64
+		// wantLease is the increment we expect to lease, 0 if the block should serve the request
+		wantLease uint64
+		want      SequenceRange
+		wantBlock sequenceBlock
+	}{
+		{
+			name:      "first request leases a block",
+			count:     1,
+			wantLease: blockSize,
+			want:      SequenceRange{Start: 1, Count: 1},
+			wantBlock: sequenceBlock{next: 2, end: 10},
+		},
+		{
+			name:      "served from the block",
+			block:     &sequenceBlock{next: 4, end: 10},
+			leased:    10,
+			count:     3,
+			want:      SequenceRange{Start: 4, Count: 3},
+			wantBlock: sequenceBlock{next: 7, end: 10},
+		},
+		{
+			name:      "served from the rest of the block",
+			block:     &sequenceBlock{next: 4, end: 10},
+			leased:    10,
+			count:     7,
+			want:      SequenceRange{Start: 4, Count: 7},
+			wantBlock: sequenceBlock{next: 11, end: 10},
+		},
+		{
+			name:      "drops the block when another node leased past it",
+			block:     &sequenceBlock{next: 4, end: 10},
+			leased:    20,
+			count:     1,
+			wantLease: blockSize,
+			want:      SequenceRange{Start: 21, Count: 1},
+			wantBlock: sequenceBlock{next: 22, end: 30},
+		},
+		{
+			name:      "extends the block when nothing was leased in between",
+			block:     &sequenceBlock{next: 8, end: 10},
+			leased:    10,
+			count:     5,
+			wantLease: blockSize,
+			want:      SequenceRange{Start: 8, Count: 5},
+			wantBlock: sequenceBlock{next: 13, end: 20},
+		},
+		{
+			name:      "exhausted block is extended",
+			block:     &sequenceBlock{next: 11, end: 10},
+			leased:    10,
+			count:     1,
+			wantLease: blockSize,
+			want:      SequenceRange{Start: 11, Count: 1},
+			wantBlock: sequenceBlock{next: 12, end: 20},
+		},
+		{
+			name:          "does not extend the block over another node's lease",
+			block:         &sequenceBlock{next: 8, end: 10},
+			leased:        10,
+			leasedBetween: blockSize,
+			count:         5,
+			wantLease:     blockSize,
+			want:          SequenceRange{Start: 21, Count: 5},
+			wantBlock:     sequenceBlock{next: 26, end: 30},
+		},
+		{
+			name:      "count greater than the block size leases count",
+			count:     25,
+			wantLease: 25,
+			want:      SequenceRange{Start: 1, Count: 25},
+			wantBlock: sequenceBlock{next: 26, end: 25},
+		},
+		{
+			name:      "count greater than what is left extends by count",
+			block:     &sequenceBlock{next: 8, end: 10},
+			leased:    10,
+			count:     25,
+			wantLease: 25,
+			want:      SequenceRange{Start: 8, Count: 25},
+			wantBlock: sequenceBlock{next: 33, end: 35},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &MetaHost{sequenceBlockSize: blockSize, sequences: map[string]*sequenceBlock{}}
+			if tt.block != nil {
+				block := *tt.block
+				m.sequences["s"] = &block
+			}
+
+			leased := tt.leased + tt.leasedBetween
+			var leasedIncr uint64
+			got, err := m.takeSequence("s", tt.count, tt.leased, func(incr uint64) (uint64, error) {
+				leasedIncr = incr
+				leased += incr
+				return leased, nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLease, leasedIncr)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantBlock, *m.sequences["s"])
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package raft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/danthegoodman1/EpicEpoch/gologger"
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+	"github.com/lni/dragonboat/v3/statemachine"
+	"github.com/rs/zerolog"
+	"io"
+	"os"
+)
+
+// MetaClusterID is the raft group of the meta state machine, which holds everything that isn't an epoch
+const MetaClusterID = 99
+
+const (
+	// MetaRejected is the update result of a lock or safepoint command that did not change anything
+	MetaRejected uint64 = iota + 1
+	// MetaAccepted is the update result of a lock or safepoint command that was applied
+	MetaAccepted
+)
+
+type (
+	// MetaStateMachine is a sibling of the EpochStateMachine in its own raft group, so that its (larger)
+	// state never slows down proposing epochs
+	MetaStateMachine struct {
+		ClusterID uint64
+		NodeID    uint64
+		MetaFile  string
+		state     PersistenceMeta
+		closed    bool
+		logger    zerolog.Logger
+	}
+
+	PersistenceMeta struct {
+		RaftIndex uint64
+		// Sequences is the last value leased of every named sequence
+		Sequences map[string]uint64
+		// LeaseClock is the latest time (unix nanoseconds) carried by a lock or safepoint command, from the clock
+		// committed along with the issuer's epochs (see PersistenceEpoch.Clock). Expiries are judged against it rather
+		// than each replica's clock, so every replica agrees on whether a lease or registration has expired.
+		LeaseClock uint64
+		// Locks is the latest lease of every named lock, including expired and released leases so that
+		// fencing tokens never go backwards
+		Locks map[string]Lock
+		// GCSafepoint is the oldest timestamp still in use, which only moves forward
+		GCSafepoint timestamp.Timestamp
+		// Safepoints are the registered min active read timestamps, by service
+		Safepoints map[string]SafepointRegistration
+	}
+
+	// MetaCommand is a proposal to the meta state machine
+	MetaCommand struct {
+		// Sequence, if set, increments the named sequence by Incr. The update result is the new value.
+		Sequence string `json:",omitempty"`
+		Incr     uint64 `json:",omitempty"`
+		// Lock, if set, changes a lock. The update result is MetaAccepted or MetaRejected, with the lease as the data.
+		Lock *LockCommand `json:",omitempty"`
+		// Safepoint, if set, changes a safepoint registration. The update result is MetaAccepted or MetaRejected,
+		// with the safepoint as the data.
+		Safepoint *SafepointCommand `json:",omitempty"`
+	}
+
+	// sequenceLookup looks up the last value leased of a sequence
+	sequenceLookup string
+	// lockLookup looks up the latest lease of a lock, and whether it is held as of the lease clock or Now if later
+	lockLookup struct {
+		Name string
+		Now  uint64
+	}
+)
+
+func NewMetaStateMachine(clusterID, nodeID uint64) statemachine.IOnDiskStateMachine {
+	sm := &MetaStateMachine{
+		ClusterID: clusterID,
+		NodeID:    nodeID,
+		MetaFile:  fmt.Sprintf("./meta-%d.json", nodeID),
+		state:     PersistenceMeta{},
+		logger:    gologger.NewLogger(),
+	}
+	sm.state.init()
+
+	return sm
+}
+
+func (m *MetaStateMachine) Open(stopChan <-chan struct{}) (uint64, error) {
+	m.logger.Debug().Msg("open")
+	if _, err := os.Stat(m.MetaFile); errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		logger.Fatal().Err(err).Msg("error opening meta persistence file")
+	}
+
+	fileBytes, err := os.ReadFile(m.MetaFile)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("error reading meta persistence file")
+	}
+
+	err = json.Unmarshal(fileBytes, &m.state)
+	if err != nil {
+		logger.Fatal().Err(err).Msg("error deserializing meta persistence file, is it corrupted?")
+	}
+	m.state.init()
+
+	return m.state.RaftIndex, nil
+}
+
+// init makes sure the maps exist after deserializing
+func (p *PersistenceMeta) init() {
+	if p.Sequences == nil {
+		p.Sequences = map[string]uint64{}
+	}
+	if p.Locks == nil {
+		p.Locks = map[string]Lock{}
+	}
+	if p.Safepoints == nil {
+		p.Safepoints = map[string]SafepointRegistration{}
+	}
+}
+
+func (m *MetaStateMachine) Update(entries []statemachine.Entry) ([]statemachine.Entry, error) {
+	m.logger.Debug().Interface("entries", entries).Msg("update")
+	if m.closed {
+		panic("Update called after close!")
+	}
+
+	for i, entry := range entries {
+		var cmd MetaCommand
+		err := json.Unmarshal(entry.Cmd, &cmd)
+		if err != nil {
+			return nil, fmt.Errorf("error in json.Unmarshal: %w", err)
+		}
+
+		if cmd.Sequence != "" {
+			m.state.Sequences[cmd.Sequence] += cmd.Incr
+			entries[i].Result = statemachine.Result{Value: m.state.Sequences[cmd.Sequence]}
+		}
+		if cmd.Lock != nil {
+			accepted, lock := m.state.applyLock(*cmd.Lock)
+			lock.Held = lock.ExpiresAt > m.state.LeaseClock
+			entries[i].Result = statemachine.Result{Value: accepted, Data: utils.MustMarshal(lock)}
+		}
+		if cmd.Safepoint != nil {
+			accepted, sp := m.state.applySafepoint(*cmd.Safepoint)
+			entries[i].Result = statemachine.Result{Value: accepted, Data: utils.MustMarshal(sp)}
+		}
+	}
+
+	m.state.RaftIndex = entries[len(entries)-1].Index
+
+	err := WriteFileAtomic(m.MetaFile, utils.MustMarshal(m.state), 0777)
+	if err != nil {
+		return nil, fmt.Errorf("error writing atomically to file %s: %w", m.MetaFile, err)
+	}
+
+	return entries, nil
+}
+
+func (m *MetaStateMachine) Lookup(i interface{}) (interface{}, error) {
+	m.logger.Debug().Interface("lookup", i).Msg("lookup")
+	if m.closed {
+		return nil, ErrAlreadyClosed
+	}
+
+	switch q := i.(type) {
+	case sequenceLookup:
+		return m.state.Sequences[string(q)], nil
+	case lockLookup:
+		lock := m.state.Locks[q.Name]
+		lock.Held = lock.ExpiresAt > max(m.state.LeaseClock, q.Now)
+		return lock, nil
+	case safepointLookup:
+		return m.state.safepointAt(q.Now), nil
+	}
+	return nil, fmt.Errorf("unknown meta lookup %T", i)
+}
+
+func (m *MetaStateMachine) Sync() error {
+	m.logger.Debug().Msg("sync")
+	if m.closed {
+		panic("Sync called after close!")
+	}
+	// Because we write atomically in Update, we do not need to do anything here
+	return nil
+}
+
+func (m *MetaStateMachine) PrepareSnapshot() (interface{}, error) {
+	m.logger.Debug().Msg("prepare snapshot")
+	if m.closed {
+		panic("PrepareSnapshot called after close!")
+	}
+
+	return utils.MustMarshal(m.state), nil
+}
+
+func (m *MetaStateMachine) SaveSnapshot(i interface{}, writer io.Writer, stopChan <-chan struct{}) error {
+	m.logger.Debug().Msg("save snapshot")
+	if m.closed {
+		panic("SaveSnapshot called after close!")
+	}
+
+	serializedMeta, ok := i.([]byte)
+	if !ok {
+		return fmt.Errorf("prepared snapshot was not bytes")
+	}
+
+	_, err := writer.Write(serializedMeta)
+	if err != nil {
+		return fmt.Errorf("error in writer.Write: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MetaStateMachine) RecoverFromSnapshot(reader io.Reader, stopChan <-chan struct{}) error {
+	m.logger.Debug().Msg("recover from snapshot")
+	if m.closed {
+		panic("RecoverFromSnapshot called after close!")
+	}
+
+	serializedMeta, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("error in io.ReadAll: %w", err)
+	}
+
+	// First, save to memory to make sure it is good
+	var state PersistenceMeta
+	err = json.Unmarshal(serializedMeta, &state)
+	if err != nil {
+		return fmt.Errorf("error in json.Unmarshal: %w", err)
+	}
+	state.init()
+	m.state = state
+
+	// Then write it to disk
+	err = WriteFileAtomic(m.MetaFile, serializedMeta, 0777)
+	if err != nil {
+		return fmt.Errorf("error in WriteFileAtomic: %w", err)
+	}
+
+	return nil
+}
+
+func (m *MetaStateMachine) Close() error {
+	m.logger.Debug().Msg("close")
+	if m.closed {
+		panic("already closed state machine!")
+	}
+	m.closed = true
+	return nil
+}
@@ -0,0 +1,82 @@
+package raft
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	metricBatchSize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "epicepoch",
+		Name:      "batch_size",
+		Help:      "Number of pending requests served per raft read",
+		Buckets:   prometheus.ExponentialBuckets(1, 4, 10),
+	})
+	metricSyncReadSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "epicepoch",
+		Name:      "sync_read_seconds",
+		Help:      "Latency of the linearizable raft read shared by a batch",
+		Buckets:   prometheus.ExponentialBuckets(0.00005, 2, 14),
+	})
+	metricLingerSeconds = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "epicepoch",
+		Name:      "batch_linger_seconds",
+		Help:      "Time the reader agent waited for more requests before reading",
+		Buckets:   prometheus.ExponentialBuckets(0.000005, 2, 14),
+	})
+	metricLingerExits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "epicepoch",
+		Name:      "batch_linger_exits_total",
+		Help:      "Why the reader agent stopped lingering, either the linger window elapsed or the max batch size was reached",
+	}, []string{"reason"})
+	metricLingerMicros = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "epicepoch",
+		Name:      "batch_linger_micros",
+		Help:      "Currently configured batch linger window in microseconds",
+	})
+	metricMaxBatchSize = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "epicepoch",
+		Name:      "batch_max_size",
+		Help:      "Currently configured number of pending requests that ends the linger window early",
+	})
+	metricAbandonedRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "epicepoch",
+		Name:      "abandoned_requests_total",
+		Help:      "Pending requests skipped by the reader agent because the caller had already given up",
+	})
+	metricFailedHandoffs = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "epicepoch",
+		Name:      "failed_handoffs_total",
+		Help:      "Generated timestamps that could not be handed back to the caller",
+	})
+	metricShedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "epicepoch",
+		Name:      "shed_requests_total",
+		Help:      "Requests rejected by admission control, either because the request buffer was full or because they exceeded the queue time budget",
+	}, []string{"reason", "priority"})
+	metricMinEpochProposals = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "epicepoch",
+		Name:      "min_epoch_proposals_total",
+		Help:      "New epochs proposed because a request's min timestamp was at or beyond the current epoch",
+	})
+	metricSequenceLeases = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "epicepoch",
+		Name:      "sequence_leases_total",
+		Help:      "Blocks of sequence values leased through raft",
+	})
+	metricSequenceBlocksDropped = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "epicepoch",
+		Name:      "sequence_blocks_dropped_total",
+		Help:      "Leased blocks of sequence values dropped because another node leased a block after them",
+	})
+	metricLogicalExhaustedProposals = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "epicepoch",
+		Name:      "logical_exhausted_proposals_total",
+		Help:      "New epochs proposed early because the logical bits of packed timestamps ran out",
+	})
+	metricIDRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "epicepoch",
+		Name:      "id_retries_total",
+		Help:      "Issued timestamps dropped because they did not fit in the requested id format, and were issued again from a later epoch",
+	}, []string{"format"})
+)
@@ -0,0 +1,121 @@
+package raft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+
+	"github.com/danthegoodman1/EpicEpoch/utils"
+	"golang.org/x/time/rate"
+)
+
+// DefaultNamespace is used by requests that don't name a namespace, and is configured by the env vars
+const DefaultNamespace = "default"
+
+var (
+	ErrInvalidNamespace = errors.New("namespace does not exist")
+	// ErrQuotaExceeded is returned when a namespace has been issued more timestamps than its quota allows
+	ErrQuotaExceeded = errors.New("namespace timestamp quota exceeded")
+	// ErrCountExceedsQuota is returned when more timestamps are requested at once than the namespace quota ever allows
+	ErrCountExceedsQuota = errors.New("count exceeds a second's worth of the namespace timestamp quota")
+)
+
+type (
+	// NamespaceConfig is the config of a namespace, zero values use the same defaults as the default namespace
+	NamespaceConfig struct {
+		EpochIntervalMS uint64 `json:"epochIntervalMs"`
+		// RequestBuffer is the length of the request buffer of each shard, including any overflow
+		RequestBuffer int    `json:"requestBuffer"`
+		Shards        uint64 `json:"shards"`
+		// QuotaPerSecond limits how many timestamps this node issues for the namespace per second across all of
+		// its shards, with bursts of up to a second's worth. 0 is unlimited.
+		QuotaPerSecond float64 `json:"quotaPerSecond"`
+	}
+
+	// Namespace is an independent timestamp oracle, with its own raft groups (shards), request buffers,
+	// and reader agents so that a burst in one namespace can't degrade another.
+	// Timestamps from different namespaces are not unique or ordered with respect to each other.
+	Namespace struct {
+		Name   string
+		Config NamespaceConfig
+		shards []*EpochHost
+	}
+)
+
+// parseNamespaces parses the NAMESPACES env var, a JSON object of namespace names to their config,
+// and fills in the defaults
+func parseNamespaces(env string) (map[string]NamespaceConfig, error) {
+	namespaces := map[string]NamespaceConfig{}
+	if env != "" {
+		err := json.Unmarshal([]byte(env), &namespaces)
+		if err != nil {
+			return nil, fmt.Errorf("error in json.Unmarshal: %w", err)
+		}
+	}
+
+	for name, cfg := range namespaces {
+		if name == "" {
+			return nil, fmt.Errorf("namespace name must not be empty")
+		}
+		if cfg.QuotaPerSecond < 0 {
+			return nil, fmt.Errorf("quotaPerSecond of namespace %s must not be negative", name)
+		}
+		namespaces[name] = cfg.withDefaults()
+	}
+	if _, ok := namespaces[DefaultNamespace]; !ok {
+		namespaces[DefaultNamespace] = NamespaceConfig{}.withDefaults()
+	}
+	return namespaces, nil
+}
+
+func (cfg NamespaceConfig) withDefaults() NamespaceConfig {
+	if cfg.EpochIntervalMS == 0 {
+		cfg.EpochIntervalMS = utils.EpochIntervalMS
+	}
+	if cfg.RequestBuffer == 0 {
+		cfg.RequestBuffer = int(utils.TimestampRequestBuffer + utils.TimestampOverflowBuffer)
+	}
+	if cfg.Shards == 0 {
+		cfg.Shards = uint64(utils.Shards)
+	}
+	return cfg
+}
+
+// namespaceClusterID is the raft cluster ID of the first shard of a namespace, which must be the same on every node.
+// The default namespace keeps the original cluster IDs, other namespaces are derived from a hash of the name
+// with the high bit set so they never collide with the default namespace.
+func namespaceClusterID(name string) uint64 {
+	if name == DefaultNamespace {
+		return ClusterID
+	}
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	// Leave room for the shards
+	return (h.Sum64() | 1<<63) &^ (1<<16 - 1)
+}
+
+// newQuota returns the rate limiter for the namespace quota, or nil if it is unlimited
+func (cfg NamespaceConfig) newQuota() *rate.Limiter {
+	if cfg.QuotaPerSecond == 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(cfg.QuotaPerSecond), int(math.Ceil(cfg.QuotaPerSecond)))
+}
+
+// Shard returns the EpochHost of a shard
+func (n *Namespace) Shard(shard uint64) (*EpochHost, error) {
+	if shard >= uint64(len(n.shards)) {
+		return nil, fmt.Errorf("%w: %d (there are %d shards)", ErrInvalidShard, shard, len(n.shards))
+	}
+	return n.shards[shard], nil
+}
+
+// ShardForKey returns the EpochHost of the shard that a key hashes to, clients that need ordering per key
+// must always use the same shard for a key
+func (n *Namespace) ShardForKey(key string) *EpochHost {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return n.shards[h.Sum64()%uint64(len(n.shards))]
+}
@@ -0,0 +1,160 @@
+package raft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/utils"
+	"github.com/lni/dragonboat/v3"
+)
+
+var ErrInvalidShard = errors.New("shard does not exist")
+
+// Oracle is every namespace and shard hosted on this node. Each shard is its own raft group and EpochHost, so
+// the leaders of different shards (and their load) can be spread across nodes. Timestamps are unique
+// across the shards of a namespace, but are only ordered within a shard.
+type Oracle struct {
+	nodeHost   *dragonboat.NodeHost
+	namespaces map[string]*Namespace
+	meta       *MetaHost
+}
+
+// Meta returns the host of the meta raft group
+func (o *Oracle) Meta() *MetaHost {
+	return o.meta
+}
+
+// Namespace returns a namespace by name, an empty name is the default namespace
+func (o *Oracle) Namespace(name string) (*Namespace, error) {
+	if name == "" {
+		name = DefaultNamespace
+	}
+	ns, ok := o.namespaces[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidNamespace, name)
+	}
+	return ns, nil
+}
+
+// ShardFor picks the shard of a namespace from an explicit shard number or a key, a key takes precedence
+func (o *Oracle) ShardFor(namespace string, shard uint64, key string) (*EpochHost, error) {
+	ns, err := o.Namespace(namespace)
+	if err != nil {
+		return nil, err
+	}
+	if key != "" {
+		return ns.ShardForKey(key), nil
+	}
+	return ns.Shard(shard)
+}
+
+// GetMembership returns the membership of the first shard of a namespace, along with the leader of every shard
+// and of the meta raft group
+func (o *Oracle) GetMembership(ctx context.Context, namespace string) (*Membership, error) {
+	ns, err := o.Namespace(namespace)
+	if err != nil {
+		return nil, err
+	}
+	m, err := ns.shards[0].GetMembership(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error in EpochHost.GetMembership: %w", err)
+	}
+
+	if leader, available, err := o.meta.GetLeader(); err == nil && available {
+		for _, member := range m.Members {
+			if member.NodeID == leader {
+				m.MetaLeader = &member
+			}
+		}
+	}
+
+	if len(ns.shards) == 1 {
+		return m, nil
+	}
+
+	for _, eh := range ns.shards {
+		membership, err := eh.GetMembership(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error in EpochHost.GetMembership for shard %d: %w", eh.shard, err)
+		}
+		m.ShardLeaders = append(m.ShardLeaders, membership.Leader)
+	}
+	return m, nil
+}
+
+// epochHosts returns every shard of every namespace
+func (o *Oracle) epochHosts() []*EpochHost {
+	var hosts []*EpochHost
+	for _, ns := range o.namespaces {
+		hosts = append(hosts, ns.shards...)
+	}
+	return hosts
+}
+
+// SetBatching updates the batching behavior of every shard of every namespace
+func (o *Oracle) SetBatching(cfg BatchingConfig) {
+	for _, eh := range o.epochHosts() {
+		eh.SetBatching(cfg)
+	}
+}
+
+// GetBatching returns the batching config, which is the same for every shard
+func (o *Oracle) GetBatching() BatchingConfig {
+	return o.namespaces[DefaultNamespace].shards[0].GetBatching()
+}
+
+func (o *Oracle) Stop() {
+	for _, eh := range o.epochHosts() {
+		eh.Stop()
+	}
+	o.nodeHost.Stop()
+}
+
+// spreadLeadership waits for the shard to elect a leader, then if we won it, hands leadership to the
+// preferred node of the shard so that shard leaders are spread across nodes. It is only done once on
+// start, as a node being down could otherwise have leadership bounce around.
+func (e *EpochHost) spreadLeadership() {
+	var leader uint64
+	for {
+		var available bool
+		var err error
+		leader, available, err = e.GetLeader()
+		if err != nil {
+			logger.Error().Err(err).Uint64("shard", e.shard).Msg("error getting leader id, not spreading shard leadership")
+			return
+		}
+		if available {
+			break
+		}
+		time.Sleep(time.Millisecond * time.Duration(raftRttMs) * 10)
+	}
+	if leader != utils.NodeID {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(raftRttMs)*100)
+	defer cancel()
+	membership, err := e.nodeHost.SyncGetClusterMembership(ctx, e.clusterID)
+	if err != nil {
+		logger.Error().Err(err).Uint64("shard", e.shard).Msg("error in nodeHost.SyncGetClusterMembership, not spreading shard leadership")
+		return
+	}
+	var nodeIDs []uint64
+	for id := range membership.Nodes {
+		nodeIDs = append(nodeIDs, id)
+	}
+	slices.Sort(nodeIDs)
+
+	preferred := nodeIDs[e.shard%uint64(len(nodeIDs))]
+	if preferred == utils.NodeID {
+		return
+	}
+	logger.Info().Uint64("shard", e.shard).Uint64("preferred", preferred).Msg("transferring shard leadership to its preferred node")
+	err = e.nodeHost.RequestLeaderTransfer(e.clusterID, preferred)
+	if err != nil {
+		logger.Error().Err(err).Uint64("shard", e.shard).Msg("error in nodeHost.RequestLeaderTransfer")
+	}
+}
@@ -0,0 +1,47 @@
+package raft
+
+import (
+	"errors"
+
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+)
+
+type TimestampLayout string
+
+const (
+	// LayoutHybrid issues 16 byte hybrid timestamps, a nanosecond epoch followed by a 64 bit index (the default)
+	LayoutHybrid TimestampLayout = "hybrid"
+	// LayoutPacked issues 8 byte timestamps of a millisecond followed by PACKED_LOGICAL_BITS of index.
+	// Every epoch is a whole millisecond, and a new one is proposed early when the logical bits run out.
+	LayoutPacked TimestampLayout = "packed"
+)
+
+var (
+	ErrInvalidTimestampLayout = errors.New("TIMESTAMP_LAYOUT must be one of hybrid or packed")
+	// ErrCountExceedsLogical is returned when more timestamps are requested than fit in the logical bits of one epoch
+	ErrCountExceedsLogical = errors.New("count exceeds the logical bits of a packed timestamp")
+)
+
+func ParseTimestampLayout(s string) (TimestampLayout, error) {
+	switch layout := TimestampLayout(s); layout {
+	case "", LayoutHybrid:
+		return LayoutHybrid, nil
+	case LayoutPacked:
+		return layout, nil
+	}
+	return "", ErrInvalidTimestampLayout
+}
+
+// Packing is how timestamps are packed into a uint64 in the packed layout, or nil in the hybrid layout
+func (e *EpochHost) Packing() *timestamp.Packing {
+	return e.packing
+}
+
+// wholeMillis rounds an epoch up to a whole millisecond in the packed layout, so every epoch packs
+// to a different millisecond
+func (e *EpochHost) wholeMillis(epoch uint64) uint64 {
+	if e.packing == nil || epoch%1e6 == 0 {
+		return epoch
+	}
+	return epoch - epoch%1e6 + 1e6
+}
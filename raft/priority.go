@@ -0,0 +1,135 @@
+package raft
+
+import (
+	"errors"
+	"github.com/danthegoodman1/EpicEpoch/ring"
+)
+
+type Priority int
+
+const (
+	PriorityHigh Priority = iota
+	PriorityNormal
+	PriorityLow
+
+	numPriorities = 3
+)
+
+var (
+	ErrInvalidPriority = errors.New("priority must be one of high, normal, or low")
+
+	// priorityWeights is how many requests of each priority the reader agent serves per round
+	priorityWeights = [numPriorities]int{8, 4, 1}
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityHigh:
+		return "high"
+	case PriorityLow:
+		return "low"
+	default:
+		return "normal"
+	}
+}
+
+// ParsePriority parses a priority hint from a client, an empty hint is normal priority
+func ParsePriority(s string) (Priority, error) {
+	switch s {
+	case "high":
+		return PriorityHigh, nil
+	case "", "normal":
+		return PriorityNormal, nil
+	case "low":
+		return PriorityLow, nil
+	default:
+		return PriorityNormal, ErrInvalidPriority
+	}
+}
+
+// requestQueue holds pending requests of every priority in arrival order, in a single ring so that
+// the buffer is only allocated once however requests are spread across priorities
+type requestQueue struct {
+	queue *ring.RingBuffer[*pendingRead]
+
+	// shedAt is the total number of pending requests (across all priorities) at which
+	// new requests of each priority are shed, so lower priorities are shed first
+	shedAt [numPriorities]int
+}
+
+func newRequestQueue(capacity, normalShedPercent, lowShedPercent int) *requestQueue {
+	return &requestQueue{
+		queue: ring.NewRingBuffer[*pendingRead](uint64(capacity)),
+		shedAt: [numPriorities]int{
+			capacity,
+			capacity * normalShedPercent / 100,
+			capacity * lowShedPercent / 100,
+		},
+	}
+}
+
+// Len returns the total number of pending requests
+func (q *requestQueue) Len() int {
+	return int(q.queue.Len())
+}
+
+// Capacity returns the total number of pending requests beyond which all requests are shed
+func (q *requestQueue) Capacity() int {
+	return q.shedAt[PriorityHigh]
+}
+
+// offer adds the pending request to the queue, returning false if it should be shed
+func (q *requestQueue) offer(pr *pendingRead) (bool, error) {
+	if q.Len() >= q.shedAt[pr.priority] {
+		return false, nil
+	}
+	return q.queue.Offer(pr)
+}
+
+// take dequeues n pending requests, and returns them in the order they should be served
+func (q *requestQueue) take(n int) ([]*pendingRead, error) {
+	var byPriority [numPriorities][]*pendingRead
+	for range n {
+		pr, err := q.queue.Get()
+		if err != nil {
+			return nil, err
+		}
+		byPriority[pr.priority] = append(byPriority[pr.priority], pr)
+	}
+
+	var counts [numPriorities]int
+	for p, prs := range byPriority {
+		counts[p] = len(prs)
+	}
+	ordered := make([]*pendingRead, 0, n)
+	for _, p := range weightedOrder(counts) {
+		ordered = append(ordered, byPriority[p][0])
+		byPriority[p] = byPriority[p][1:]
+	}
+	return ordered, nil
+}
+
+func (q *requestQueue) dispose() {
+	q.queue.Dispose()
+}
+
+// weightedOrder returns the order in which to dequeue the pending requests, using weighted
+// round-robin so that higher priorities are served first without starving lower priorities
+func weightedOrder(counts [numPriorities]int) []Priority {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+
+	order := make([]Priority, 0, total)
+	for len(order) < total {
+		for p := range counts {
+			take := min(counts[p], priorityWeights[p])
+			for range take {
+				order = append(order, Priority(p))
+			}
+			counts[p] -= take
+		}
+	}
+	return order
+}
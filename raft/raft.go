@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
 	"github.com/danthegoodman1/EpicEpoch/utils"
 	"github.com/lni/dragonboat/v3"
 	"github.com/lni/dragonboat/v3/config"
 	dragonlogger "github.com/lni/dragonboat/v3/logger"
+	"github.com/lni/dragonboat/v3/statemachine"
+	"golang.org/x/time/rate"
 	"os"
 	"path/filepath"
 	"sync/atomic"
@@ -18,17 +21,48 @@ const ClusterID = 100
 
 var raftRttMs = uint64(utils.GetEnvOrDefaultInt("RTT_MS", 10))
 
-func StartRaft() (*EpochHost, error) {
+func StartRaft() (*Oracle, error) {
 	nodeID := utils.NodeID
-	rc := config.Config{
-		NodeID:             nodeID,
-		ClusterID:          ClusterID,
-		ElectionRTT:        10,
-		HeartbeatRTT:       1,
-		CheckQuorum:        true,
-		SnapshotEntries:    10,
-		CompactionOverhead: 5,
+	issueMode, err := ParseIssueMode(utils.IssueMode)
+	if err != nil {
+		return nil, err
+	}
+	if issueMode == IssueModeBounded && nodeID >= 1<<nodeBits {
+		return nil, fmt.Errorf("NODE_ID must be less than %d in the bounded issue mode", 1<<nodeBits)
 	}
+	layout, err := ParseTimestampLayout(utils.TimestampLayout)
+	if err != nil {
+		return nil, err
+	}
+	var packing *timestamp.Packing
+	if layout == LayoutPacked {
+		packing = &timestamp.Packing{LogicalBits: int(utils.PackedLogicalBits)}
+		if err := packing.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid PACKED_LOGICAL_BITS: %w", err)
+		}
+		if issueMode == IssueModeBounded {
+			return nil, fmt.Errorf("the packed timestamp layout requires the leader issue mode, as nodes would pack the same milliseconds")
+		}
+	}
+	if err := SnowflakeLayout.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid snowflake layout: %w", err)
+	}
+	namespaces, err := parseNamespaces(utils.Namespaces)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing NAMESPACES: %w", err)
+	}
+	for name, cfg := range namespaces {
+		if cfg.Shards < 1 || cfg.Shards > 1<<utils.ShardBits {
+			return nil, fmt.Errorf("shards of namespace %s must be between 1 and %d", name, 1<<utils.ShardBits)
+		}
+		if issueMode == IssueModeBounded && cfg.Shards > 1 {
+			return nil, fmt.Errorf("shards of namespace %s must be 1 in the bounded issue mode, as every node already issues timestamps", name)
+		}
+		if packing != nil && cfg.Shards > 1 {
+			return nil, fmt.Errorf("shards of namespace %s must be 1 in the packed timestamp layout, as there is no room for the shard in the logical bits", name)
+		}
+	}
+
 	datadir := filepath.Join("_raft", fmt.Sprintf("node%d", nodeID))
 	nhc := config.NodeHostConfig{
 		WALDir:         datadir,
@@ -42,28 +76,96 @@ func StartRaft() (*EpochHost, error) {
 		panic(err)
 	}
 
-	err = nh.StartOnDiskCluster(map[uint64]dragonboat.Target{
+	o := &Oracle{nodeHost: nh, namespaces: map[string]*Namespace{}}
+	for name, cfg := range namespaces {
+		ns := &Namespace{Name: name, Config: cfg}
+		quota := cfg.newQuota()
+		for shard := uint64(0); shard < cfg.Shards; shard++ {
+			eh, err := startEpochHost(nh, ns, shard, quota, issueMode, packing)
+			if err != nil {
+				return nil, fmt.Errorf("error starting shard %d of namespace %s: %w", shard, name, err)
+			}
+			ns.shards = append(ns.shards, eh)
+		}
+		o.namespaces[name] = ns
+	}
+
+	o.meta, err = startMetaHost(nh)
+	if err != nil {
+		return nil, fmt.Errorf("error starting the meta raft group: %w", err)
+	}
+
+	return o, nil
+}
+
+// startEpochHost starts the raft group of a shard of a namespace, with its own state machine, reader agent, and epoch ticker
+func startEpochHost(nh *dragonboat.NodeHost, ns *Namespace, shard uint64, quota *rate.Limiter, issueMode IssueMode, packing *timestamp.Packing) (*EpochHost, error) {
+	nodeID := utils.NodeID
+	clusterID := namespaceClusterID(ns.Name) + shard
+	interval := time.Millisecond * time.Duration(ns.Config.EpochIntervalMS)
+	rc := config.Config{
+		NodeID:             nodeID,
+		ClusterID:          clusterID,
+		ElectionRTT:        10,
+		HeartbeatRTT:       1,
+		CheckQuorum:        true,
+		SnapshotEntries:    10,
+		CompactionOverhead: 5,
+	}
+
+	// The watcher and issuer exist before the state machine, so they see the state loaded from disk
+	watcher := newEpochWatcher()
+	bounded := &boundedIssuer{nodeID: nodeID, startedAt: time.Now()}
+	err := nh.StartOnDiskCluster(map[uint64]dragonboat.Target{
 		1: "localhost:60001",
 		2: "localhost:60002",
 		3: "localhost:60003",
-	}, false, NewEpochStateMachine, rc)
+	}, false, func(clusterID, nodeID uint64) statemachine.IOnDiskStateMachine {
+		sm := NewEpochStateMachine(clusterID, nodeID).(*EpochStateMachine)
+		sm.OnApply = func(state PersistenceEpoch) {
+			watcher.set(state.Epoch)
+			bounded.driftIntervalMicros.Store(state.DriftIntervalMicros)
+		}
+		return sm
+	}, rc)
 	if err != nil {
 		return nil, fmt.Errorf("error in StartOnDiskCluster: %w", err)
 	}
 
 	eh := &EpochHost{
 		nodeHost:            nh,
+		clusterID:           clusterID,
+		namespace:           ns.Name,
+		shard:               shard,
 		epochIndex:          atomic.Uint64{},
 		lastEpoch:           atomic.Uint64{},
 		readerAgentStopChan: make(chan struct{}),
-		requestChan:         make(chan *pendingRead, utils.TimestampRequestBuffer),
+		requestQueue:        newRequestQueue(ns.Config.RequestBuffer, int(utils.NormalPriorityShedPercent), int(utils.LowPriorityShedPercent)),
 		readerAgentReading:  atomic.Bool{},
 		pokeChan:            make(chan struct{}),
-		updateTicker:        time.NewTicker(time.Millisecond * time.Duration(utils.EpochIntervalMS)),
+		epochInterval:       interval,
+		updateTicker:        time.NewTicker(interval),
+		quota:               quota,
+		queueBudget:         time.Millisecond * time.Duration(utils.QueueBudgetMS),
+		appliedEpoch:        watcher,
+		issueMode:           issueMode,
+		bounded:             bounded,
+		snowflakes:          newSnowflakeGuard(),
+		packing:             packing,
+	}
+	if ns.Config.Shards > 1 {
+		// Reserve the high bits of the index for the shard, so timestamps are unique across shards
+		eh.indexPrefix = shard << (64 - utils.ShardBits)
+		eh.indexPrefixBits = int(utils.ShardBits)
+		go eh.spreadLeadership()
 	}
 	eh.epochIndex.Store(0)
 	eh.lastEpoch.Store(0)
 	eh.readerAgentReading.Store(false)
+	eh.SetBatching(BatchingConfig{
+		LingerMicros: utils.BatchLingerMicros,
+		MaxBatchSize: utils.BatchMaxSize,
+	})
 
 	// Debug log loop
 	go func() {
@@ -75,26 +177,35 @@ func StartRaft() (*EpochHost, error) {
 				logger.Warn().Msg("ticker channel closed, returning")
 				return
 			}
-			leader, available, err := nh.GetLeaderID(ClusterID)
+			leader, available, err := nh.GetLeaderID(eh.clusterID)
 			if err != nil {
 				logger.Fatal().Err(err).Msg("error getting leader id, crashing")
 				return
 			}
 			// logger.Debug().Err(err).Msgf("Leader=%d available=%+v", leader, available)
-			if available && leader == utils.NodeID {
+			// In the bounded issue mode, every node issues from its own clock so there are no epochs to propose
+			if available && leader == utils.NodeID && !eh.IssuesLocally() {
 				// Update the epoch
 				newEpoch := uint64(time.Now().UnixNano())
-				if newEpoch <= eh.lastEpoch.Load() && !warnedClockDrift {
-					warnedClockDrift = true
-					logger.Error().Uint64("newEpoch", newEpoch).Uint64("lastEpoch", eh.lastEpoch.Load()).Msg("new epoch less than last epoch, there must be clock drift, incrementing new epoch by 1")
-					newEpoch++
+				if lastEpoch := eh.lastEpoch.Load(); newEpoch <= lastEpoch {
+					// The last epoch may also be ahead of our clock because a client requested a min timestamp
+					if !warnedClockDrift {
+						warnedClockDrift = true
+						logger.Error().Uint64("newEpoch", newEpoch).Uint64("lastEpoch", lastEpoch).Msg("new epoch less than last epoch, there must be clock drift, using last epoch + 1")
+					}
+					newEpoch = lastEpoch + 1
 				} else {
 					warnedClockDrift = false // re-enable the warn trigger
 					deadlines = 0            // reset the deadlines counter
 				}
 
 				// Write the new value
-				err = eh.proposeNewEpoch(newEpoch)
+				_, err = eh.proposeNewEpoch(newEpoch)
+				if errors.Is(err, dragonboat.ErrClusterNotReady) {
+					// Leadership is being transferred, the new leader will propose its own epoch
+					logger.Warn().Err(err).Uint64("shard", eh.shard).Msg("cluster not ready proposing new epoch, skipping")
+					continue
+				}
 				if errors.Is(err, context.DeadlineExceeded) {
 					deadlines++
 					logger.Error().Str("crashTreshold", fmt.Sprintf("%d/%d", deadlines, utils.EpochIntervalDeadlineLimit)).Msg("deadline exceeded proposing new epoch")
@@ -0,0 +1,154 @@
+package raft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+)
+
+var (
+	ErrInvalidService = errors.New("service name must not be empty")
+	// ErrBelowSafepoint is returned when registering a timestamp below the GC safepoint, as data at that
+	// timestamp may already have been garbage collected
+	ErrBelowSafepoint = errors.New("timestamp is below the GC safepoint")
+)
+
+type (
+	// Safepoint is the GC safepoint, the oldest timestamp still in use, along with the registrations it was computed from
+	Safepoint struct {
+		Safepoint timestamp.Timestamp `json:"safepoint"`
+		// Services are the live registrations of min active read timestamps, by service
+		Services map[string]SafepointRegistration `json:"services"`
+	}
+
+	SafepointRegistration struct {
+		Timestamp timestamp.Timestamp `json:"timestamp"`
+		// ExpiresAt is the time (unix nanoseconds) by the lease clock at which the registration expires
+		ExpiresAt uint64 `json:"expiresAt,string"`
+	}
+
+	// SafepointCommand registers (or removes) the min active read timestamp of a service in the meta state machine
+	SafepointCommand struct {
+		Service   string
+		Timestamp timestamp.Timestamp
+		// Now is the issuer's lease clock when the command was proposed, which the registration expires TTL after
+		Now    uint64
+		TTL    time.Duration
+		Remove bool `json:",omitempty"`
+	}
+
+	// safepointLookup looks up the safepoint as of the lease clock, or Now if later
+	safepointLookup struct {
+		Now uint64
+	}
+)
+
+// applySafepoint applies a safepoint command, returning whether it was accepted along with the resulting safepoint.
+// Expired registrations are dropped first, then the safepoint moves forward to the min of the live registrations.
+func (p *PersistenceMeta) applySafepoint(cmd SafepointCommand) (uint64, Safepoint) {
+	p.LeaseClock = max(p.LeaseClock, cmd.Now)
+	for service, reg := range p.Safepoints {
+		if reg.ExpiresAt <= p.LeaseClock {
+			delete(p.Safepoints, service)
+		}
+	}
+
+	accepted := MetaAccepted
+	switch {
+	case cmd.Remove:
+		delete(p.Safepoints, cmd.Service)
+	case cmd.Timestamp.Compare(p.GCSafepoint) < 0:
+		accepted = MetaRejected
+	default:
+		p.Safepoints[cmd.Service] = SafepointRegistration{Timestamp: cmd.Timestamp, ExpiresAt: p.LeaseClock + uint64(cmd.TTL)}
+	}
+
+	sp := p.safepointAt(p.LeaseClock)
+	p.GCSafepoint = sp.Safepoint
+	return accepted, sp
+}
+
+// safepointAt computes the safepoint from the registrations that are live as of now (or the lease clock if later),
+// copying them so they can be handed out of the state machine. It doesn't change the stored safepoint.
+func (p *PersistenceMeta) safepointAt(now uint64) Safepoint {
+	now = max(p.LeaseClock, now)
+	sp := Safepoint{Safepoint: p.GCSafepoint, Services: make(map[string]SafepointRegistration, len(p.Safepoints))}
+
+	// With no live registrations nothing is known to be in use, but it isn't known to be safe to move forward either
+	var oldest timestamp.Timestamp
+	for service, reg := range p.Safepoints {
+		if reg.ExpiresAt <= now {
+			continue
+		}
+		sp.Services[service] = reg
+		if oldest == (timestamp.Timestamp{}) || reg.Timestamp.Compare(oldest) < 0 {
+			oldest = reg.Timestamp
+		}
+	}
+	if oldest.Compare(sp.Safepoint) > 0 {
+		sp.Safepoint = oldest
+	}
+	return sp
+}
+
+// RegisterSafepoint registers the min active read timestamp of a service for ttl, so the safepoint
+// can't move past it until it is removed or expires
+func (o *Oracle) RegisterSafepoint(ctx context.Context, service string, ts timestamp.Timestamp, ttl time.Duration) (Safepoint, error) {
+	if service == "" {
+		return Safepoint{}, ErrInvalidService
+	}
+	return o.proposeSafepoint(ctx, SafepointCommand{Service: service, Timestamp: ts, TTL: ttl})
+}
+
+// RemoveSafepoint removes the registration of a service, letting the safepoint move forward
+func (o *Oracle) RemoveSafepoint(ctx context.Context, service string) (Safepoint, error) {
+	if service == "" {
+		return Safepoint{}, ErrInvalidService
+	}
+	return o.proposeSafepoint(ctx, SafepointCommand{Service: service, Remove: true})
+}
+
+// GetSafepoint returns the GC safepoint from any node, as of the lease clock. Registrations that expired since
+// the latest safepoint command are left out, and the safepoint moved past them, even before the next command drops them.
+func (o *Oracle) GetSafepoint(ctx context.Context) (Safepoint, error) {
+	now, err := o.Issuer().LeaseClock(ctx)
+	if err != nil {
+		return Safepoint{}, fmt.Errorf("error in EpochHost.LeaseClock: %w", err)
+	}
+	sp, err := o.meta.nodeHost.SyncRead(ctx, o.meta.clusterID, safepointLookup{Now: now})
+	if err != nil {
+		return Safepoint{}, fmt.Errorf("error in nodeHost.SyncRead: %w", err)
+	}
+	return sp.(Safepoint), nil
+}
+
+// proposeSafepoint proposes a safepoint command to the meta raft group with the lease clock
+func (o *Oracle) proposeSafepoint(ctx context.Context, cmd SafepointCommand) (Safepoint, error) {
+	var err error
+	cmd.Now, err = o.Issuer().LeaseClock(ctx)
+	if err != nil {
+		return Safepoint{}, fmt.Errorf("error in EpochHost.LeaseClock: %w", err)
+	}
+
+	session := o.meta.nodeHost.GetNoOPSession(o.meta.clusterID)
+	res, err := o.meta.nodeHost.SyncPropose(ctx, session, utils.MustMarshal(MetaCommand{Safepoint: &cmd}))
+	if err != nil {
+		return Safepoint{}, fmt.Errorf("error in nodeHost.SyncPropose: %w", err)
+	}
+
+	var sp Safepoint
+	err = json.Unmarshal(res.Data, &sp)
+	if err != nil {
+		return Safepoint{}, fmt.Errorf("error in json.Unmarshal: %w", err)
+	}
+	if res.Value == MetaRejected {
+		return sp, ErrBelowSafepoint
+	}
+
+	return sp, nil
+}
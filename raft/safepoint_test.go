@@ -0,0 +1,150 @@
+package raft
+
+import (
+	"testing"
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplySafepointExpiry(t *testing.T) {
+	const now = uint64(1720000000000000000)
+	ttl := 10 * time.Second
+	p := &PersistenceMeta{}
+	p.init()
+
+	ts1 := timestamp.Timestamp{Epoch: now - uint64(time.Minute), Index: 1}
+	ts2 := timestamp.Timestamp{Epoch: now, Index: 1}
+	result, sp := p.applySafepoint(SafepointCommand{Service: "a", Timestamp: ts1, Now: now, TTL: ttl})
+	assert.Equal(t, MetaAccepted, result)
+	assert.Equal(t, ts1, sp.Safepoint)
+	result, sp = p.applySafepoint(SafepointCommand{Service: "b", Timestamp: ts2, Now: now, TTL: 2 * ttl})
+	assert.Equal(t, MetaAccepted, result)
+	assert.Equal(t, ts1, sp.Safepoint)
+	assert.Len(t, sp.Services, 2)
+
+	// Still live just before it expires
+	_, sp = p.applySafepoint(SafepointCommand{Service: "b", Timestamp: ts2, Now: now + uint64(ttl) - 1, TTL: 2 * ttl})
+	assert.Equal(t, ts1, sp.Safepoint)
+	assert.Contains(t, sp.Services, "a")
+
+	// Once a expires it is dropped, and the safepoint moves forward to b
+	_, sp = p.applySafepoint(SafepointCommand{Service: "b", Timestamp: ts2, Now: now + uint64(ttl), TTL: 2 * ttl})
+	assert.Equal(t, ts2, sp.Safepoint)
+	assert.NotContains(t, sp.Services, "a")
+	assert.Equal(t, now+uint64(ttl)+uint64(2*ttl), sp.Services["b"].ExpiresAt)
+}
+
+func TestApplySafepointLeaseClock(t *testing.T) {
+	const now = uint64(1720000000000000000)
+	ttl := 10 * time.Second
+	p := &PersistenceMeta{}
+	p.init()
+
+	ts := timestamp.Timestamp{Epoch: now, Index: 1}
+	p.applySafepoint(SafepointCommand{Service: "a", Timestamp: ts, Now: now, TTL: ttl})
+
+	// A registration at a timestamp far ahead of the clock (like one moved by a min timestamp) doesn't expire a
+	ahead := timestamp.Timestamp{Epoch: now + uint64(time.Minute), Index: 1}
+	_, sp := p.applySafepoint(SafepointCommand{Service: "b", Timestamp: ahead, Now: now + 1, TTL: ttl})
+	assert.Equal(t, ts, sp.Safepoint)
+	assert.Contains(t, sp.Services, "a")
+
+	// The lease clock never goes backwards, even if a proposer's clock does
+	_, sp = p.applySafepoint(SafepointCommand{Service: "b", Timestamp: ahead, Now: now + uint64(ttl), TTL: ttl})
+	assert.NotContains(t, sp.Services, "a")
+	_, sp = p.applySafepoint(SafepointCommand{Service: "c", Timestamp: ahead, Now: now, TTL: ttl})
+	assert.Equal(t, now+uint64(ttl), p.LeaseClock)
+	assert.Equal(t, now+uint64(2*ttl), sp.Services["c"].ExpiresAt)
+}
+
+func TestApplySafepointRemove(t *testing.T) {
+	const now = uint64(1720000000000000000)
+	ttl := 10 * time.Second
+	p := &PersistenceMeta{}
+	p.init()
+
+	ts1 := timestamp.Timestamp{Epoch: now, Index: 1}
+	ts2 := timestamp.Timestamp{Epoch: now, Index: 2}
+	p.applySafepoint(SafepointCommand{Service: "a", Timestamp: ts1, Now: now, TTL: ttl})
+	p.applySafepoint(SafepointCommand{Service: "b", Timestamp: ts2, Now: now, TTL: ttl})
+
+	result, sp := p.applySafepoint(SafepointCommand{Service: "a", Remove: true, Now: now})
+	assert.Equal(t, MetaAccepted, result)
+	assert.Equal(t, ts2, sp.Safepoint)
+	assert.NotContains(t, sp.Services, "a")
+
+	// Removing the last registration leaves the safepoint where it is
+	result, sp = p.applySafepoint(SafepointCommand{Service: "b", Remove: true, Now: now})
+	assert.Equal(t, MetaAccepted, result)
+	assert.Equal(t, ts2, sp.Safepoint)
+	assert.Empty(t, sp.Services)
+
+	// Removing a service that isn't registered is a no-op
+	result, sp = p.applySafepoint(SafepointCommand{Service: "c", Remove: true, Now: now})
+	assert.Equal(t, MetaAccepted, result)
+	assert.Equal(t, ts2, sp.Safepoint)
+}
+
+func TestApplySafepointOnlyMovesForward(t *testing.T) {
+	const now = uint64(1720000000000000000)
+	ttl := 10 * time.Second
+	p := &PersistenceMeta{}
+	p.init()
+
+	ts1 := timestamp.Timestamp{Epoch: now, Index: 1}
+	ts2 := timestamp.Timestamp{Epoch: now, Index: 2}
+	p.applySafepoint(SafepointCommand{Service: "a", Timestamp: ts2, Now: now, TTL: ttl})
+
+	// Registering below the safepoint is rejected, and doesn't change it
+	result, sp := p.applySafepoint(SafepointCommand{Service: "b", Timestamp: ts1, Now: now, TTL: ttl})
+	assert.Equal(t, MetaRejected, result)
+	assert.Equal(t, ts2, sp.Safepoint)
+	assert.NotContains(t, sp.Services, "b")
+
+	// Even a service moving its own registration back can't move the safepoint back
+	result, sp = p.applySafepoint(SafepointCommand{Service: "a", Timestamp: ts1, Now: now, TTL: ttl})
+	assert.Equal(t, MetaRejected, result)
+	assert.Equal(t, ts2, sp.Safepoint)
+	assert.Equal(t, ts2, sp.Services["a"].Timestamp)
+
+	// Registering at the safepoint is allowed
+	result, sp = p.applySafepoint(SafepointCommand{Service: "b", Timestamp: ts2, Now: now, TTL: ttl})
+	assert.Equal(t, MetaAccepted, result)
+	assert.Equal(t, ts2, sp.Safepoint)
+}
+
+func TestSafepointAt(t *testing.T) {
+	const now = uint64(1720000000000000000)
+	ttl := 10 * time.Second
+	p := &PersistenceMeta{}
+	p.init()
+
+	ts1 := timestamp.Timestamp{Epoch: now, Index: 1}
+	ts2 := timestamp.Timestamp{Epoch: now, Index: 2}
+	p.applySafepoint(SafepointCommand{Service: "a", Timestamp: ts1, Now: now, TTL: ttl})
+	p.applySafepoint(SafepointCommand{Service: "b", Timestamp: ts2, Now: now, TTL: 2 * ttl})
+
+	sp := p.safepointAt(now + uint64(ttl) - 1)
+	assert.Equal(t, ts1, sp.Safepoint)
+	assert.Len(t, sp.Services, 2)
+
+	// Once a expires it is left out at lookup, even though no command has dropped it yet
+	sp = p.safepointAt(now + uint64(ttl))
+	assert.Equal(t, ts2, sp.Safepoint)
+	assert.NotContains(t, sp.Services, "a")
+	assert.Equal(t, ts1, p.GCSafepoint)
+	assert.Contains(t, p.Safepoints, "a")
+
+	// A lookup with an older clock is judged by the lease clock
+	p.applySafepoint(SafepointCommand{Service: "b", Timestamp: ts2, Now: now + uint64(ttl), TTL: 2 * ttl})
+	sp = p.safepointAt(now)
+	assert.Equal(t, ts2, sp.Safepoint)
+	assert.NotContains(t, sp.Services, "a")
+
+	// With every registration expired, the safepoint stays where it is
+	sp = p.safepointAt(now + uint64(10*ttl))
+	assert.Equal(t, ts2, sp.Safepoint)
+	assert.Empty(t, sp.Services)
+}
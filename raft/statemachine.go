@@ -22,11 +22,20 @@ type (
 		epoch     PersistenceEpoch
 		closed    bool
 		logger    zerolog.Logger
+		// OnApply, if set, is called with the current state whenever it is loaded or updated
+		OnApply func(state PersistenceEpoch)
 	}
 
 	PersistenceEpoch struct {
 		RaftIndex uint64
 		Epoch     uint64
+		// DriftIntervalMicros is the interval time is truncated to in the bounded issue mode, 0 uses DRIFT_INTERVAL_US.
+		// Proposals that only set this change the config, rather than the epoch.
+		DriftIntervalMicros uint64 `json:",omitempty"`
+		// Clock is the newest time (unix nanoseconds) committed along with an epoch, the epoch capped at the
+		// proposing leader's clock. A min timestamp can push the epoch ahead of the clock, but not this, so lease
+		// expiries are judged by it. Proposals that only set this advance it, rather than the epoch.
+		Clock uint64 `json:",omitempty"`
 	}
 )
 
@@ -34,8 +43,19 @@ var (
 	logger = gologger.NewLogger()
 )
 
+const (
+	// EpochRejected is the update result of a proposed epoch that was not newer than the current epoch
+	EpochRejected uint64 = iota + 1
+	// EpochAccepted is the update result of a proposed epoch that became the current epoch
+	EpochAccepted
+)
+
 func NewEpochStateMachine(clusterID, nodeID uint64) statemachine.IOnDiskStateMachine {
 	epochFile := fmt.Sprintf("./epoch-%d.json", nodeID) // TODO make this configurable
+	if clusterID != ClusterID {
+		// Every shard after the first has its own file
+		epochFile = fmt.Sprintf("./epoch-%d-%d.json", nodeID, clusterID)
+	}
 
 	sm := &EpochStateMachine{
 		ClusterID: clusterID,
@@ -69,38 +89,61 @@ func (e *EpochStateMachine) Open(stopChan <-chan struct{}) (uint64, error) {
 		}
 	}
 
+	e.notifyApply()
 	return e.epoch.RaftIndex, nil
 }
 
+func (e *EpochStateMachine) notifyApply() {
+	if e.OnApply != nil {
+		e.OnApply(e.epoch)
+	}
+}
+
 func (e *EpochStateMachine) Update(entries []statemachine.Entry) ([]statemachine.Entry, error) {
 	e.logger.Debug().Interface("entries", entries).Msg("update")
 	if e.closed {
 		panic("Update called after close!")
 	}
 
-	// Since all writes are to the same key, we can just take the last one and write it
-	// Update it in memory
-	// Verify that it's newer than the current time
-	var newEpoch PersistenceEpoch
-	err := json.Unmarshal(entries[len(entries)-1].Cmd, &newEpoch)
-	if err != nil {
-		return nil, fmt.Errorf("error in json.Unmarshal: %w", err)
-	}
+	// Since all writes are to the same key, we only need to write the result of the batch.
+	// An epoch must be newer than the current one, otherwise it is rejected. Epochs can be
+	// proposed concurrently (e.g. the epoch ticker racing a client's min timestamp), so a stale
+	// proposal is expected, and it must not return an error as that would crash every replica.
+	for i, entry := range entries {
+		var newEpoch PersistenceEpoch
+		err := json.Unmarshal(entry.Cmd, &newEpoch)
+		if err != nil {
+			return nil, fmt.Errorf("error in json.Unmarshal: %w", err)
+		}
 
-	if newEpoch.Epoch <= e.epoch.Epoch {
-		return nil, fmt.Errorf("update epoch was not greater than the current epoch, there is clock drift or a bug")
+		if newEpoch.Epoch == 0 && (newEpoch.DriftIntervalMicros > 0 || newEpoch.Clock > 0) {
+			if newEpoch.DriftIntervalMicros > 0 {
+				e.epoch.DriftIntervalMicros = newEpoch.DriftIntervalMicros
+			}
+			e.epoch.Clock = max(e.epoch.Clock, newEpoch.Clock)
+			entries[i].Result = statemachine.Result{Value: EpochAccepted}
+			continue
+		}
+
+		if newEpoch.Epoch <= e.epoch.Epoch {
+			e.logger.Warn().Uint64("newEpoch", newEpoch.Epoch).Uint64("currentEpoch", e.epoch.Epoch).Msg("rejecting epoch that is not greater than the current epoch")
+			entries[i].Result = statemachine.Result{Value: EpochRejected, Data: utils.MustMarshal(e.epoch)}
+			continue
+		}
+		// Otherwise we can update it
+		e.epoch.Epoch = newEpoch.Epoch
+		e.epoch.Clock = max(e.epoch.Clock, newEpoch.Clock)
+		entries[i].Result = statemachine.Result{Value: EpochAccepted}
 	}
-	// Otherwise we can update it
-	e.epoch = newEpoch
 
 	e.epoch.RaftIndex = entries[len(entries)-1].Index
 
-	err = WriteFileAtomic(e.EpochFile, utils.MustMarshal(e.epoch), 0777)
+	err := WriteFileAtomic(e.EpochFile, utils.MustMarshal(e.epoch), 0777)
 	if err != nil {
 		return nil, fmt.Errorf("error writing atomically to file %s: %w", e.EpochFile, err)
 	}
 
-	// Just return the entries, there isn't a status code here, it either worked or exploded
+	e.notifyApply()
 	return entries, nil
 }
 
@@ -177,6 +220,7 @@ func (e *EpochStateMachine) RecoverFromSnapshot(reader io.Reader, stopChan <-cha
 		return fmt.Errorf("error in WriteFileAtomic: %w", err)
 	}
 
+	e.notifyApply()
 	return nil
 }
 
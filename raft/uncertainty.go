@@ -0,0 +1,59 @@
+package raft
+
+import (
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/utils"
+)
+
+var maxClockError = time.Microsecond * time.Duration(utils.MaxClockErrorMicros)
+
+// TimeInterval is a TrueTime-style bound on the current time, in unix nanoseconds.
+// The true time is guaranteed to be within [Earliest, Latest] as long as no clock is off by more than MAX_CLOCK_ERROR_US.
+type TimeInterval struct {
+	Earliest uint64 `json:"earliest,string"`
+	Latest   uint64 `json:"latest,string"`
+	// Epoch is the committed epoch the interval was computed from, or the current drift interval in the bounded issue mode
+	Epoch uint64 `json:"epoch,string"`
+	// Uncertainty is the widest an interval can be on any node of the cluster (the epoch interval, or DRIFT_INTERVAL_US
+	// in the bounded issue mode, plus twice MAX_CLOCK_ERROR_US), which is how long a client must wait out to be sure
+	// a time has passed
+	Uncertainty time.Duration `json:"uncertaintyNs"`
+}
+
+// Now returns the bounds of the current time, from the locally applied epoch and the local clock.
+// It can be served by any node.
+func (e *EpochHost) Now() (TimeInterval, error) {
+	if e.IssuesLocally() {
+		// Timestamps come straight from the clock of each node, so the only bound is the clock error
+		now := time.Now()
+		return TimeInterval{
+			Earliest:    uint64(now.Add(-maxClockError).UnixNano()),
+			Latest:      uint64(now.Add(maxClockError).UnixNano()),
+			Epoch:       e.bounded.truncatedNow(),
+			Uncertainty: e.bounded.uncertainty(),
+		}, nil
+	}
+
+	epoch := e.appliedEpoch.get()
+	if epoch == 0 {
+		return TimeInterval{}, ErrNoAppliedEpoch
+	}
+
+	// The true time is past our own clock minus the clock error. The epoch can't bound the earliest time,
+	// as a client's min timestamp can move it ahead of the clock. A new epoch is committed every interval,
+	// so the true time is before the next one, but if the epochs are stalled our own clock still bounds the latest time.
+	now := uint64(time.Now().UnixNano())
+	clockError := uint64(maxClockError)
+	// In the packed layout epochs are rounded up to the next millisecond, so they can be that far ahead of the clock
+	var rounding uint64
+	if e.packing != nil {
+		rounding = uint64(time.Millisecond)
+	}
+	return TimeInterval{
+		Earliest:    now - clockError,
+		Latest:      max(epoch+uint64(e.epochInterval), now) + clockError,
+		Epoch:       epoch,
+		Uncertainty: e.epochInterval + time.Duration(rounding) + 2*maxClockError,
+	}, nil
+}
@@ -0,0 +1,139 @@
+package resp_server
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxPipeline is the most commands read from a connection before they are executed together
+	maxPipeline = 1024
+	// maxArgs is the most arguments a command can have
+	maxArgs = 64
+	// maxBulkLen is the longest argument a command can have
+	maxBulkLen = 64 * 1024
+)
+
+var ErrProtocol = errors.New("protocol error")
+
+// readCommand reads a command, either a RESP array of bulk strings or an inline command of space separated
+// words like redis-cli and telnet send. Empty inline commands are skipped.
+func readCommand(r *bufio.Reader) ([]string, error) {
+	for {
+		line, err := readLine(r)
+		if err != nil {
+			return nil, err
+		}
+		if len(line) == 0 || line[0] != '*' {
+			if args := strings.Fields(line); len(args) > 0 {
+				return args, nil
+			}
+			continue
+		}
+
+		n, err := strconv.Atoi(line[1:])
+		if err != nil || n > maxArgs {
+			return nil, fmt.Errorf("%w: invalid multibulk length", ErrProtocol)
+		}
+		if n <= 0 {
+			continue
+		}
+		args := make([]string, 0, n)
+		for i := 0; i < n; i++ {
+			arg, err := readBulk(r)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+		}
+		return args, nil
+	}
+}
+
+// readPipeline reads a command, then every other command the client has already sent, up to maxPipeline.
+// A protocol error is returned with the commands read before it, which should still be executed.
+func readPipeline(r *bufio.Reader) ([][]string, error) {
+	cmd, err := readCommand(r)
+	if err != nil {
+		return nil, err
+	}
+	cmds := [][]string{cmd}
+	for len(cmds) < maxPipeline && r.Buffered() > 0 {
+		cmd, err := readCommand(r)
+		if err != nil {
+			return cmds, err
+		}
+		cmds = append(cmds, cmd)
+	}
+	return cmds, nil
+}
+
+func readBulk(r *bufio.Reader) (string, error) {
+	line, err := readLine(r)
+	if err != nil {
+		return "", err
+	}
+	if len(line) == 0 || line[0] != '$' {
+		return "", fmt.Errorf("%w: expected '$', got '%s'", ErrProtocol, line)
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n < 0 || n > maxBulkLen {
+		return "", fmt.Errorf("%w: invalid bulk length", ErrProtocol)
+	}
+
+	buf := make([]byte, n+2)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", err
+	}
+	if buf[n] != '\r' || buf[n+1] != '\n' {
+		return "", fmt.Errorf("%w: bulk string not terminated by CRLF", ErrProtocol)
+	}
+	return string(buf[:n]), nil
+}
+
+// readLine reads a line without its CRLF, or LF for inline commands
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		return "", fmt.Errorf("%w: line too long", ErrProtocol)
+	}
+	if err != nil {
+		return "", err
+	}
+	line = line[:len(line)-1]
+	if len(line) > 0 && line[len(line)-1] == '\r' {
+		line = line[:len(line)-1]
+	}
+	return string(line), nil
+}
+
+func writeSimple(w *bufio.Writer, s string) {
+	w.WriteString("+" + s + "\r\n")
+}
+
+// writeError writes an error reply, msg starts with the error code such as ERR or MOVED
+func writeError(w *bufio.Writer, msg string) {
+	// Error replies can't span lines
+	w.WriteString("-" + strings.NewReplacer("\r", " ", "\n", " ").Replace(msg) + "\r\n")
+}
+
+func writeInteger(w *bufio.Writer, n int64) {
+	w.WriteString(":" + strconv.FormatInt(n, 10) + "\r\n")
+}
+
+func writeBulk(w *bufio.Writer, s string) {
+	w.WriteString("$" + strconv.Itoa(len(s)) + "\r\n" + s + "\r\n")
+}
+
+func writeNullBulk(w *bufio.Writer) {
+	w.WriteString("$-1\r\n")
+}
+
+// writeArray writes the header of an array of n replies, which must be written next
+func writeArray(w *bufio.Writer, n int) {
+	w.WriteString("*" + strconv.Itoa(n) + "\r\n")
+}
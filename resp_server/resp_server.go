@@ -0,0 +1,298 @@
+package resp_server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/frontend"
+	"github.com/danthegoodman1/EpicEpoch/gologger"
+	"github.com/danthegoodman1/EpicEpoch/raft"
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+)
+
+var logger = gologger.NewLogger()
+
+// RESPServer serves timestamps from the first shard of the default namespace over the Redis protocol,
+// so any Redis client can get timestamps
+type RESPServer struct {
+	Oracle   *raft.Oracle
+	listener net.Listener
+	// advertiseAddrs are the addresses clients reach each node's RESP listener at, by node ID
+	advertiseAddrs map[uint64]string
+	conns          *frontend.Conns
+}
+
+// StartRESPServer starts the RESP listener on RESP_PORT, or returns nil if it is not set
+func StartRESPServer(oracle *raft.Oracle) *RESPServer {
+	if utils.RESPPort == "" {
+		return nil
+	}
+	advertiseAddrs, err := utils.ParseNodeAddrs(utils.RESPAdvertiseAddrs)
+	if err != nil {
+		logger.Error().Err(err).Msg("error parsing RESP_ADVERTISE_ADDRS, exiting")
+		os.Exit(1)
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", utils.RESPPort))
+	if err != nil {
+		logger.Error().Err(err).Msg("error creating tcp listener, exiting")
+		os.Exit(1)
+	}
+
+	s := &RESPServer{
+		Oracle:         oracle,
+		listener:       listener,
+		advertiseAddrs: advertiseAddrs,
+		conns:          frontend.NewConns("resp", listener),
+	}
+
+	logger.Info().Msg("starting resp server on " + listener.Addr().String())
+	go frontend.AcceptLoop(s.conns, listener.Accept, s.serve)
+
+	return s
+}
+
+// serve reads commands from the connection until it closes. Every command the client pipelined is
+// executed together, and the replies are written in a single flush.
+func (s *RESPServer) serve(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	for {
+		cmds, err := readPipeline(r)
+		quit := s.execute(w, cmds)
+		if errors.Is(err, ErrProtocol) {
+			writeError(w, "ERR "+err.Error())
+		}
+		if flushErr := w.Flush(); flushErr != nil || quit || err != nil {
+			if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, ErrProtocol) && !errors.Is(err, net.ErrClosed) {
+				logger.Debug().Err(err).Msg("error reading from resp connection")
+			}
+			return
+		}
+	}
+}
+
+// execute writes the reply of every command in order, and returns whether the client quit
+func (s *RESPServer) execute(w *bufio.Writer, cmds [][]string) bool {
+	for i := 0; i < len(cmds); {
+		switch strings.ToUpper(cmds[i][0]) {
+		case "TS.GET":
+			// Consecutive TS.GETs are collapsed into one request. Other commands are not reordered
+			// around them, so a TS.READ still sees every timestamp issued before it.
+			i += s.getTimestamps(w, cmds[i:])
+			continue
+		case "TS.READ":
+			s.readTimestamp(w, cmds[i])
+		case "TS.MEMBERS":
+			s.members(w, cmds[i])
+		case "PING":
+			switch len(cmds[i]) {
+			case 1:
+				writeSimple(w, "PONG")
+			case 2:
+				writeBulk(w, cmds[i][1])
+			default:
+				writeArityError(w, cmds[i][0])
+			}
+		case "COMMAND":
+			// redis-cli asks for command docs on connect
+			writeArray(w, 0)
+		case "QUIT":
+			writeSimple(w, "OK")
+			return true
+		default:
+			writeError(w, fmt.Sprintf("ERR unknown command '%s'", cmds[i][0]))
+		}
+		i++
+	}
+	return false
+}
+
+// getTimestamps issues the timestamps of the leading run of TS.GET commands in a single request,
+// and returns how many commands it replied to
+func (s *RESPServer) getTimestamps(w *bufio.Writer, cmds [][]string) int {
+	eh := s.Oracle.Issuer()
+	limit := int(utils.TimestampMaxCount)
+	if packing := eh.Packing(); packing != nil {
+		limit = min(limit, int(packing.MaxLogical()))
+	}
+	if burst := eh.QuotaBurst(); burst > 0 {
+		limit = min(limit, burst)
+	}
+
+	// counts[i] is 0 if command i is invalid, so it gets an error reply instead
+	var counts []int
+	var errs []string
+	total := 0
+	for _, cmd := range cmds {
+		if !strings.EqualFold(cmd[0], "TS.GET") {
+			break
+		}
+		count, errMsg := parseCount(cmd, limit)
+		if total+count > limit {
+			break
+		}
+		counts = append(counts, count)
+		errs = append(errs, errMsg)
+		total += count
+	}
+
+	var reserved timestamp.Range
+	var errMsg string
+	if total > 0 {
+		reserved, errMsg = s.issue(eh, total)
+	}
+
+	offset := uint64(0)
+	for i, count := range counts {
+		switch {
+		case errs[i] != "":
+			writeError(w, errs[i])
+		case errMsg != "":
+			writeError(w, errMsg)
+		case len(cmds[i]) == 1:
+			writeBulk(w, s.format(eh, reserved.At(offset)))
+		default:
+			writeArray(w, count)
+			for j := 0; j < count; j++ {
+				writeBulk(w, s.format(eh, reserved.At(offset+uint64(j))))
+			}
+		}
+		offset += uint64(count)
+	}
+	return len(counts)
+}
+
+func parseCount(cmd []string, limit int) (int, string) {
+	switch len(cmd) {
+	case 1:
+		return 1, ""
+	case 2:
+		count, err := strconv.Atoi(cmd[1])
+		if err != nil || count < 1 || count > limit {
+			return 0, fmt.Sprintf("ERR count must be a number between 1 and %d", limit)
+		}
+		return count, ""
+	}
+	return 0, arityError(cmd[0])
+}
+
+func (s *RESPServer) issue(eh *raft.EpochHost, count int) (timestamp.Range, string) {
+	if errMsg := s.checkLeader(eh); errMsg != "" {
+		return timestamp.Range{}, errMsg
+	}
+
+	ctx, cancel := context.WithTimeout(s.conns.Ctx, time.Second)
+	defer cancel()
+	reserved, err := eh.GetUniqueTimestamp(ctx, raft.TimestampRequest{Count: count, Priority: raft.PriorityNormal})
+	if err != nil {
+		return reserved, errorReply(fmt.Errorf("error in EpochHost.GetUniqueTimestamp: %w", err))
+	}
+	return reserved, ""
+}
+
+func (s *RESPServer) readTimestamp(w *bufio.Writer, cmd []string) {
+	if len(cmd) != 1 {
+		writeArityError(w, cmd[0])
+		return
+	}
+	eh := s.Oracle.Issuer()
+	if errMsg := s.checkLeader(eh); errMsg != "" {
+		writeError(w, errMsg)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(s.conns.Ctx, time.Second)
+	defer cancel()
+	ts, err := eh.GetReadTimestamp(ctx, raft.PriorityNormal)
+	if err != nil {
+		writeError(w, errorReply(fmt.Errorf("error in EpochHost.GetReadTimestamp: %w", err)))
+		return
+	}
+	writeBulk(w, s.format(eh, ts))
+}
+
+// members replies with an array of [node ID, RESP address, role] for every node. The address is
+// null if it isn't in RESP_ADVERTISE_ADDRS.
+func (s *RESPServer) members(w *bufio.Writer, cmd []string) {
+	if len(cmd) != 1 {
+		writeArityError(w, cmd[0])
+		return
+	}
+	ctx, cancel := context.WithTimeout(s.conns.Ctx, time.Second)
+	defer cancel()
+	membership, err := s.Oracle.GetMembership(ctx, raft.DefaultNamespace)
+	if err != nil {
+		writeError(w, errorReply(fmt.Errorf("error in Oracle.GetMembership: %w", err)))
+		return
+	}
+
+	writeArray(w, len(membership.Members))
+	for _, m := range membership.Members {
+		writeArray(w, 3)
+		writeInteger(w, int64(m.NodeID))
+		if addr, ok := s.advertiseAddrs[m.NodeID]; ok {
+			writeBulk(w, addr)
+		} else {
+			writeNullBulk(w)
+		}
+		if m.NodeID == membership.Leader.NodeID {
+			writeBulk(w, "leader")
+		} else {
+			writeBulk(w, "follower")
+		}
+	}
+}
+
+// checkLeader returns the error reply if this node can't issue timestamps, which is a MOVED redirect
+// to the leader's RESP address when it's known
+func (s *RESPServer) checkLeader(eh *raft.EpochHost) string {
+	if err := frontend.CheckLeader(eh, s.advertiseAddrs); err != nil {
+		return errorReply(err)
+	}
+	return ""
+}
+
+// format encodes a timestamp as hex, 32 characters for hybrid timestamps and 16 for packed ones
+func (s *RESPServer) format(eh *raft.EpochHost, ts timestamp.Timestamp) string {
+	if packing := eh.Packing(); packing != nil {
+		return packing.Hex(ts)
+	}
+	return ts.Hex()
+}
+
+// errorReply maps errors to reply errors, clients should retry TRYAGAIN replies later
+func errorReply(err error) string {
+	kind, msg := frontend.Reply(err)
+	switch kind {
+	case frontend.KindTryAgain:
+		return "TRYAGAIN " + msg
+	case frontend.KindNotLeader:
+		var notLeader *frontend.NotLeaderError
+		if errors.As(err, &notLeader) && notLeader.Addr != "" {
+			// There are no hash slots, every key is served by the leader
+			return "MOVED 0 " + notLeader.Addr
+		}
+	}
+	return "ERR " + msg
+}
+
+func arityError(name string) string {
+	return fmt.Sprintf("ERR wrong number of arguments for '%s' command", strings.ToLower(name))
+}
+
+func writeArityError(w *bufio.Writer, name string) {
+	writeError(w, arityError(name))
+}
+
+func (s *RESPServer) Shutdown(ctx context.Context) error {
+	return s.conns.Shutdown(ctx)
+}
@@ -0,0 +1,63 @@
+package resp_server
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPipeline(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("*2\r\n$6\r\nTS.GET\r\n$1\r\n3\r\n\r\nTS.GET\r\n*1\r\n$7\r\nTS.READ\r\nping hello\n"))
+
+	cmds, err := readPipeline(r)
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, [][]string{{"TS.GET", "3"}, {"TS.GET"}, {"TS.READ"}, {"ping", "hello"}}, cmds)
+
+	_, err = readPipeline(r)
+	assert.True(t, errors.Is(err, io.EOF))
+}
+
+func TestReadPipelineProtocolError(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("PING\r\n*1\r\n+TS.GET\r\n"))
+
+	// The commands before the error are returned to be executed
+	cmds, err := readPipeline(r)
+	assert.True(t, errors.Is(err, ErrProtocol))
+	assert.Equal(t, [][]string{{"PING"}}, cmds)
+
+	_, err = readCommand(bufio.NewReader(strings.NewReader("*1\r\n$3\r\nPINGX\r\n")))
+	assert.True(t, errors.Is(err, ErrProtocol))
+}
+
+func TestReadPipelineIsBounded(t *testing.T) {
+	r := bufio.NewReaderSize(strings.NewReader(strings.Repeat("PING\r\n", maxPipeline+1)), maxPipeline*8)
+
+	cmds, err := readPipeline(r)
+	assert.Nil(t, err)
+	assert.Len(t, cmds, maxPipeline)
+
+	cmds, err = readPipeline(r)
+	assert.Nil(t, err)
+	assert.Len(t, cmds, 1)
+}
+
+func TestWriteReplies(t *testing.T) {
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeArray(w, 3)
+	writeInteger(w, 1)
+	writeBulk(w, "localhost:6379")
+	writeNullBulk(w)
+	writeSimple(w, "PONG")
+	writeError(w, "ERR multi\r\nline")
+	assert.Nil(t, w.Flush())
+
+	assert.Equal(t, "*3\r\n:1\r\n$14\r\nlocalhost:6379\r\n$-1\r\n+PONG\r\n-ERR multi  line\r\n", buf.String())
+}
@@ -79,8 +79,8 @@ func (rb *RingBuffer[T]) Put(item T) error {
 }
 
 // Offer adds the provided item to the queue if there is space.  If the queue
-// is full, this call will return false.  An error will be returned if the
-// queue is disposed.
+// is full, this call will return false.  Losing a race with another producer
+// does not count as full.  An error will be returned if the queue is disposed.
 func (rb *RingBuffer[T]) Offer(item T) (bool, error) {
 	return rb.put(item, true)
 }
@@ -103,14 +103,14 @@ L:
 			}
 		case dif < 0:
 			panic(`Ring buffer in a compromised state during a put operation.`)
+		case offer && int64(dif) < 0:
+			// The node at our position has not been consumed yet, so the queue is full. This is decided
+			// against the position seq was read for, as a reloaded one may be ahead of it.
+			return false, nil
 		default:
 			pos = atomic.LoadUint64(&rb.queue)
 		}
 
-		if offer {
-			return false, nil
-		}
-
 		runtime.Gosched() // free up the cpu before the next iteration
 	}
 
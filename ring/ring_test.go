@@ -150,6 +150,41 @@ func TestOffer(t *testing.T) {
 	assert.Equal(t, "bar", item)
 }
 
+func TestOfferConcurrent(t *testing.T) {
+	numThreads := 256
+	for round := 0; round < 100; round++ {
+		rb := NewRingBuffer[any](uint64(numThreads))
+
+		var wg sync.WaitGroup
+		wg.Add(numThreads)
+		var accepted uint64
+		start := make(chan struct{})
+		for i := 0; i < numThreads; i++ {
+			go func(i int) {
+				defer wg.Done()
+				<-start
+				ok, err := rb.Offer(i)
+				assert.Nil(t, err)
+				if ok {
+					atomic.AddUint64(&accepted, 1)
+				}
+			}(i)
+		}
+		close(start)
+		wg.Wait()
+
+		// There was room for every item, so contention must not have rejected any
+		if !assert.Equal(t, uint64(numThreads), accepted) {
+			return
+		}
+		assert.Equal(t, uint64(numThreads), rb.Len())
+
+		ok, err := rb.Offer(numThreads)
+		assert.Nil(t, err)
+		assert.False(t, ok)
+	}
+}
+
 func TestRingGetEmpty(t *testing.T) {
 	rb := NewRingBuffer[any](3)
 
@@ -0,0 +1,233 @@
+package tcp_server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/danthegoodman1/EpicEpoch/timestamp"
+)
+
+var (
+	// ErrTryAgain is returned when the node shed the request, it should be retried later
+	ErrTryAgain = errors.New("try again")
+	// ErrRequestFailed is returned when the node rejected the request or failed to serve it
+	ErrRequestFailed = errors.New("request failed")
+	ErrClientClosed  = errors.New("client closed")
+)
+
+// NotLeaderError is returned by a node that is not the leader, Addr is the leader's address or empty if the node doesn't know it
+type NotLeaderError struct {
+	Addr string
+}
+
+func (e *NotLeaderError) Error() string {
+	if e.Addr == "" {
+		return "node is not the leader"
+	}
+	return fmt.Sprintf("node is not the leader, the leader is at %s", e.Addr)
+}
+
+type response struct {
+	status  Status
+	payload []byte
+}
+
+// Client multiplexes concurrent requests over one connection to a node's TCP_PORT. It's safe for concurrent use.
+type Client struct {
+	conn net.Conn
+	// requests are the frames waiting to be written
+	requests chan []byte
+	// closed is closed once the connection is
+	closed chan struct{}
+
+	mu      sync.Mutex
+	nextID  uint64
+	pending map[uint64]chan response
+	// err is why the connection closed, once it has
+	err error
+}
+
+// Dial connects to the node at addr
+func Dial(ctx context.Context, addr string) (*Client, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("error dialing %s: %w", addr, err)
+	}
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		tcpConn.SetNoDelay(true)
+	}
+	return NewClient(conn), nil
+}
+
+// NewClient makes a client from an open connection, which the client closes when it's closed
+func NewClient(conn net.Conn) *Client {
+	c := &Client{
+		conn:     conn,
+		requests: make(chan []byte, maxInFlight),
+		closed:   make(chan struct{}),
+		pending:  map[uint64]chan response{},
+	}
+	go c.readLoop()
+	go c.writeLoop()
+	return c
+}
+
+// writeLoop writes requests as they're queued, flushing whenever no more are waiting, until the connection fails
+func (c *Client) writeLoop() {
+	w := bufio.NewWriter(c.conn)
+	for {
+		select {
+		case frame := <-c.requests:
+			_, err := w.Write(frame)
+			if err == nil && len(c.requests) == 0 {
+				err = w.Flush()
+			}
+			if err != nil {
+				c.fail(fmt.Errorf("error writing request: %w", err))
+				return
+			}
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+// readLoop delivers every response to the request waiting for it, until the connection fails
+func (c *Client) readLoop() {
+	r := bufio.NewReader(c.conn)
+	for {
+		id, status, payload, err := readResponse(r)
+		if err != nil {
+			c.fail(err)
+			return
+		}
+
+		c.mu.Lock()
+		ch, ok := c.pending[id]
+		delete(c.pending, id)
+		c.mu.Unlock()
+		// Requests whose context was done are no longer pending
+		if ok {
+			ch <- response{status: status, payload: payload}
+		}
+	}
+}
+
+// fail closes the client with err, failing every pending request
+func (c *Client) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	close(c.closed)
+	for id, ch := range c.pending {
+		close(ch)
+		delete(c.pending, id)
+	}
+	c.conn.Close()
+}
+
+// closedErr is the error for requests on a closed client, c.mu must be held
+func (c *Client) closedErr() error {
+	if errors.Is(c.err, ErrClientClosed) {
+		return c.err
+	}
+	return fmt.Errorf("%w: %w", ErrClientClosed, c.err)
+}
+
+// GetTimestamps gets count sequential unique timestamps, each in its binary form: 16 bytes, or 8 if the cluster
+// uses the packed layout
+func (c *Client) GetTimestamps(ctx context.Context, count int) ([][]byte, error) {
+	if count < 1 || uint64(count) > uint64(^uint32(0)) {
+		return nil, fmt.Errorf("%w: invalid count %d", ErrRequestFailed, count)
+	}
+
+	// Buffered so the read loop never blocks on a request that gave up
+	ch := make(chan response, 1)
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return nil, c.closedErr()
+	}
+	c.nextID++
+	id := c.nextID
+	c.pending[id] = ch
+	c.mu.Unlock()
+
+	// The node stops reading once a connection has too many requests in flight, so the request is only queued
+	// while ctx isn't done. A queued request is always written whole, and a late response is dropped.
+	select {
+	case c.requests <- encodeRequest(id, uint32(count)):
+	case <-ctx.Done():
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+		return nil, ctx.Err()
+	case <-c.closed:
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return nil, c.closedErr()
+	}
+
+	var res response
+	var ok bool
+	select {
+	case res, ok = <-ch:
+	case <-ctx.Done():
+		c.mu.Lock()
+		delete(c.pending, id)
+		c.mu.Unlock()
+		return nil, ctx.Err()
+	}
+	if !ok {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return nil, c.closedErr()
+	}
+
+	switch res.status {
+	case StatusOK:
+		return splitTimestamps(res.payload, count)
+	case StatusNotLeader:
+		return nil, &NotLeaderError{Addr: string(res.payload)}
+	case StatusTryAgain:
+		return nil, fmt.Errorf("%w: %s", ErrTryAgain, res.payload)
+	case StatusError:
+		return nil, fmt.Errorf("%w: %s", ErrRequestFailed, res.payload)
+	}
+	return nil, fmt.Errorf("%w: unknown status %d", ErrRequestFailed, res.status)
+}
+
+// GetTimestamp gets a single unique timestamp
+func (c *Client) GetTimestamp(ctx context.Context) ([]byte, error) {
+	timestamps, err := c.GetTimestamps(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+	return timestamps[0], nil
+}
+
+func splitTimestamps(payload []byte, count int) ([][]byte, error) {
+	size := len(payload) / count
+	if len(payload)%count != 0 || (size != timestamp.Size && size != timestamp.PackedSize) {
+		return nil, fmt.Errorf("%w: %d bytes is not %d timestamps", ErrRequestFailed, len(payload), count)
+	}
+	timestamps := make([][]byte, count)
+	for i := range timestamps {
+		timestamps[i] = payload[i*size : (i+1)*size : (i+1)*size]
+	}
+	return timestamps, nil
+}
+
+// Close closes the connection, failing every pending request
+func (c *Client) Close() error {
+	c.fail(ErrClientClosed)
+	return nil
+}
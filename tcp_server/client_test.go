@@ -0,0 +1,144 @@
+package tcp_server
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrameRoundTrip(t *testing.T) {
+	id, count, err := readRequest(bytes.NewReader(encodeRequest(42, 7)))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, uint64(42), id)
+	assert.Equal(t, uint32(7), count)
+
+	id, status, payload, err := readResponse(bytes.NewReader(encodeResponse(42, StatusNotLeader, []byte("localhost:8071"))))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, uint64(42), id)
+	assert.Equal(t, StatusNotLeader, status)
+	assert.Equal(t, "localhost:8071", string(payload))
+
+	// A request frame of the wrong length is rejected
+	bad := binary.BigEndian.AppendUint32(nil, 8)
+	_, _, err = readRequest(bytes.NewReader(append(bad, make([]byte, 8)...)))
+	assert.NotNil(t, err)
+}
+
+// fakeServer answers each batch of n requests in reverse order, with count timestamps whose first byte is the request ID
+func fakeServer(t *testing.T, conn net.Conn, n int) {
+	for {
+		type request struct {
+			id    uint64
+			count uint32
+		}
+		var batch []request
+		for len(batch) < n {
+			id, count, err := readRequest(conn)
+			if err != nil {
+				return
+			}
+			batch = append(batch, request{id, count})
+		}
+		for i := len(batch) - 1; i >= 0; i-- {
+			req := batch[i]
+			var frame []byte
+			switch req.count {
+			case 1000:
+				frame = encodeResponse(req.id, StatusNotLeader, []byte("localhost:8072"))
+			case 1001:
+				frame = encodeResponse(req.id, StatusTryAgain, []byte("overloaded"))
+			default:
+				frame = encodeResponse(req.id, StatusOK, bytes.Repeat([]byte{byte(req.id)}, 16*int(req.count)))
+			}
+			if _, err := conn.Write(frame); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}
+}
+
+func TestClientMultiplexes(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	go fakeServer(t, serverConn, 8)
+	c := NewClient(clientConn)
+	defer c.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Responses arrive in reverse, and every request still gets its own
+	var wg sync.WaitGroup
+	for i := 1; i <= 8; i++ {
+		wg.Add(1)
+		go func(count int) {
+			defer wg.Done()
+			timestamps, err := c.GetTimestamps(ctx, count)
+			if !assert.Nil(t, err) {
+				return
+			}
+			assert.Len(t, timestamps, count)
+			for _, ts := range timestamps {
+				assert.Len(t, ts, 16)
+				assert.Equal(t, timestamps[0][0], ts[0], "timestamps from another request")
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestClientErrors(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	go fakeServer(t, serverConn, 1)
+	c := NewClient(clientConn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := c.GetTimestamps(ctx, 1000)
+	var notLeader *NotLeaderError
+	if assert.True(t, errors.As(err, &notLeader)) {
+		assert.Equal(t, "localhost:8072", notLeader.Addr)
+	}
+
+	_, err = c.GetTimestamps(ctx, 1001)
+	assert.True(t, errors.Is(err, ErrTryAgain))
+
+	_, err = c.GetTimestamps(ctx, 0)
+	assert.True(t, errors.Is(err, ErrRequestFailed))
+
+	assert.Nil(t, c.Close())
+	_, err = c.GetTimestamp(ctx)
+	assert.True(t, errors.Is(err, ErrClientClosed))
+}
+
+func TestClientWriteRespectsContext(t *testing.T) {
+	// The server doesn't read at first, like a node with too many requests in flight
+	clientConn, serverConn := net.Pipe()
+	defer serverConn.Close()
+	c := NewClient(clientConn)
+	defer c.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.GetTimestamp(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+	// Once the server reads again, the connection is still usable
+	go fakeServer(t, serverConn, 1)
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	ts, err := c.GetTimestamp(ctx)
+	if assert.Nil(t, err) {
+		assert.Len(t, ts, 16)
+	}
+}
@@ -0,0 +1,88 @@
+package tcp_server
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Every frame is a 4 byte big endian length followed by that many bytes of body.
+//
+// A request body is an 8 byte request ID followed by a 4 byte count of timestamps.
+//
+// A response body is the 8 byte request ID it answers, a 1 byte Status, and a payload that depends on the status.
+// Responses can arrive in any order, so many requests can be in flight on one connection.
+
+// Status is the outcome of a request, which decides what the response payload is
+type Status uint8
+
+const (
+	// StatusOK responses carry count concatenated timestamps, 16 bytes each or 8 in the packed layout
+	StatusOK Status = iota
+	// StatusNotLeader responses carry the leader's address from TCP_ADVERTISE_ADDRS, or nothing if it's unknown
+	StatusNotLeader
+	// StatusTryAgain responses carry an error message, the request was shed and should be retried later
+	StatusTryAgain
+	// StatusError responses carry an error message, the request is invalid or failed
+	StatusError
+)
+
+const (
+	requestSize        = 12
+	responseHeaderSize = 9
+	// maxResponseSize fits the largest count of hybrid timestamps a node could be configured to issue
+	maxResponseSize = 64 * 1024 * 1024
+)
+
+func appendFrameLen(dst []byte, n int) []byte {
+	return binary.BigEndian.AppendUint32(dst, uint32(n))
+}
+
+func encodeRequest(id uint64, count uint32) []byte {
+	b := make([]byte, 0, 4+requestSize)
+	b = appendFrameLen(b, requestSize)
+	b = binary.BigEndian.AppendUint64(b, id)
+	return binary.BigEndian.AppendUint32(b, count)
+}
+
+// readRequest reads a request frame, returning an error for frames that aren't requests
+func readRequest(r io.Reader) (uint64, uint32, error) {
+	var b [4 + requestSize]byte
+	if _, err := io.ReadFull(r, b[:4]); err != nil {
+		return 0, 0, err
+	}
+	if n := binary.BigEndian.Uint32(b[:4]); n != requestSize {
+		return 0, 0, fmt.Errorf("request frame must be %d bytes, got %d", requestSize, n)
+	}
+	if _, err := io.ReadFull(r, b[4:]); err != nil {
+		return 0, 0, err
+	}
+	return binary.BigEndian.Uint64(b[4:12]), binary.BigEndian.Uint32(b[12:]), nil
+}
+
+func encodeResponse(id uint64, status Status, payload []byte) []byte {
+	b := make([]byte, 0, 4+responseHeaderSize+len(payload))
+	b = appendFrameLen(b, responseHeaderSize+len(payload))
+	b = binary.BigEndian.AppendUint64(b, id)
+	b = append(b, byte(status))
+	return append(b, payload...)
+}
+
+func readResponse(r io.Reader) (uint64, Status, []byte, error) {
+	var header [4 + responseHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:4]); err != nil {
+		return 0, 0, nil, err
+	}
+	n := binary.BigEndian.Uint32(header[:4])
+	if n < responseHeaderSize || n > maxResponseSize {
+		return 0, 0, nil, fmt.Errorf("invalid response frame length %d", n)
+	}
+	if _, err := io.ReadFull(r, header[4:]); err != nil {
+		return 0, 0, nil, err
+	}
+	payload := make([]byte, n-responseHeaderSize)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return 0, 0, nil, err
+	}
+	return binary.BigEndian.Uint64(header[4:12]), Status(header[12]), payload, nil
+}
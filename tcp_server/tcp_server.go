@@ -0,0 +1,164 @@
+package tcp_server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/danthegoodman1/EpicEpoch/frontend"
+	"github.com/danthegoodman1/EpicEpoch/gologger"
+	"github.com/danthegoodman1/EpicEpoch/raft"
+	"github.com/danthegoodman1/EpicEpoch/utils"
+)
+
+var logger = gologger.NewLogger()
+
+// maxInFlight is the most requests a connection can have in flight, more are not read until one finishes
+const maxInFlight = 1024
+
+// TCPServer serves timestamps from the first shard of the default namespace over a length-prefixed
+// binary protocol, for clients that want the least framing overhead
+type TCPServer struct {
+	Oracle   *raft.Oracle
+	listener net.Listener
+	// advertiseAddrs are the addresses clients reach each node's TCP listener at, by node ID
+	advertiseAddrs map[uint64]string
+	conns          *frontend.Conns
+}
+
+// StartTCPServer starts the binary protocol listener on TCP_PORT, or returns nil if it is not set
+func StartTCPServer(oracle *raft.Oracle) *TCPServer {
+	if utils.TCPPort == "" {
+		return nil
+	}
+	advertiseAddrs, err := utils.ParseNodeAddrs(utils.TCPAdvertiseAddrs)
+	if err != nil {
+		logger.Error().Err(err).Msg("error parsing TCP_ADVERTISE_ADDRS, exiting")
+		os.Exit(1)
+	}
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", utils.TCPPort))
+	if err != nil {
+		logger.Error().Err(err).Msg("error creating tcp listener, exiting")
+		os.Exit(1)
+	}
+
+	s := &TCPServer{
+		Oracle:         oracle,
+		listener:       listener,
+		advertiseAddrs: advertiseAddrs,
+		conns:          frontend.NewConns("tcp", listener),
+	}
+
+	logger.Info().Msg("starting binary tcp server on " + listener.Addr().String())
+	go frontend.AcceptLoop(s.conns, listener.Accept, s.serve)
+
+	return s
+}
+
+// serve handles every request on the connection concurrently, writing each response as it's ready
+func (s *TCPServer) serve(conn net.Conn) {
+	if tcpConn, ok := conn.(*net.TCPConn); ok {
+		tcpConn.SetNoDelay(true)
+	}
+
+	// Buffered for every in flight request, so handlers never block on a failed connection
+	frames := make(chan []byte, maxInFlight)
+	writerDone := make(chan struct{})
+	go func() {
+		defer close(writerDone)
+		s.writeLoop(conn, frames)
+	}()
+
+	sem := make(chan struct{}, maxInFlight)
+	var inFlight sync.WaitGroup
+	r := bufio.NewReader(conn)
+	for {
+		id, count, err := readRequest(r)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				logger.Debug().Err(err).Msg("error reading from tcp connection")
+			}
+			break
+		}
+
+		sem <- struct{}{}
+		inFlight.Add(1)
+		go func() {
+			defer inFlight.Done()
+			frames <- s.handle(id, count)
+			<-sem
+		}()
+	}
+
+	// Requests sent before the client closed its side still get responses
+	inFlight.Wait()
+	close(frames)
+	<-writerDone
+}
+
+// writeLoop writes frames as they're ready, flushing whenever no more are waiting
+func (s *TCPServer) writeLoop(conn net.Conn, frames <-chan []byte) {
+	w := bufio.NewWriter(conn)
+	failed := false
+	for frame := range frames {
+		if failed {
+			continue
+		}
+		_, err := w.Write(frame)
+		if err == nil && len(frames) == 0 {
+			err = w.Flush()
+		}
+		if err != nil {
+			// Closing the connection stops the read loop, the remaining frames are dropped
+			failed = true
+			conn.Close()
+		}
+	}
+}
+
+func (s *TCPServer) handle(id uint64, count uint32) []byte {
+	if count == 0 || int64(count) > utils.TimestampMaxCount {
+		return encodeResponse(id, StatusError, []byte(fmt.Sprintf("count must be between 1 and %d", utils.TimestampMaxCount)))
+	}
+
+	eh := s.Oracle.Issuer()
+	if err := frontend.CheckLeader(eh, s.advertiseAddrs); err != nil {
+		return errorResponse(id, err)
+	}
+
+	ctx, cancel := context.WithTimeout(s.conns.Ctx, time.Second)
+	defer cancel()
+	reserved, err := eh.GetUniqueTimestamp(ctx, raft.TimestampRequest{Count: int(count), Priority: raft.PriorityNormal})
+	if err != nil {
+		return errorResponse(id, fmt.Errorf("error in EpochHost.GetUniqueTimestamp: %w", err))
+	}
+
+	if packing := eh.Packing(); packing != nil {
+		return encodeResponse(id, StatusOK, packing.ExpandedBytes(reserved))
+	}
+	return encodeResponse(id, StatusOK, reserved.ExpandedBytes())
+}
+
+// errorResponse maps errors to response statuses, clients should retry StatusTryAgain responses later
+func errorResponse(id uint64, err error) []byte {
+	kind, msg := frontend.Reply(err)
+	switch kind {
+	case frontend.KindTryAgain:
+		return encodeResponse(id, StatusTryAgain, []byte(msg))
+	case frontend.KindNotLeader:
+		var notLeader *frontend.NotLeaderError
+		errors.As(err, &notLeader)
+		return encodeResponse(id, StatusNotLeader, []byte(notLeader.Addr))
+	}
+	return encodeResponse(id, StatusError, []byte(msg))
+}
+
+func (s *TCPServer) Shutdown(ctx context.Context) error {
+	return s.conns.Shutdown(ctx)
+}
@@ -0,0 +1,93 @@
+package timestamp
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// crockford is the Crockford base32 alphabet, which is in ascending ASCII order so encoded
+// timestamps sort lexicographically in the same order as the timestamps
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Base32Size is the length of a base32 encoded timestamp, 128 bits padded to 130
+const Base32Size = 26
+
+// Parse decodes a timestamp from any of its text encodings, which are told apart by length:
+// 32 characters is hex, 26 is base32, and anything else is decimal.
+func Parse(s string) (Timestamp, error) {
+	switch len(s) {
+	case Size * 2:
+		return ParseHex(s)
+	case Base32Size:
+		return ParseBase32(s)
+	default:
+		return ParseDecimal(s)
+	}
+}
+
+// Hex returns the 32 character lowercase hex encoding of the 16 byte timestamp
+func (t Timestamp) Hex() string {
+	return hex.EncodeToString(t.Bytes())
+}
+
+func ParseHex(s string) (Timestamp, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return Timestamp{}, fmt.Errorf("error in hex.DecodeString: %w", err)
+	}
+	return FromBytes(b)
+}
+
+// Base32 returns the fixed width, lexicographically sortable Crockford base32 encoding of the timestamp
+func (t Timestamp) Base32() string {
+	var out [Base32Size]byte
+	hi, lo := t.Epoch, t.Index
+	for i := Base32Size - 1; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		// Shift the 128 bit value right by 5
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+func ParseBase32(s string) (Timestamp, error) {
+	if len(s) != Base32Size {
+		return Timestamp{}, fmt.Errorf("base32 timestamp must be %d characters, got %d", Base32Size, len(s))
+	}
+	var hi, lo uint64
+	for i, c := range strings.ToUpper(s) {
+		v := strings.IndexRune(crockford, c)
+		if v < 0 {
+			return Timestamp{}, fmt.Errorf("invalid base32 character %q", c)
+		}
+		if i == 0 && v > 0b111 {
+			// Only the low 3 bits of the first character fit in 128 bits
+			return Timestamp{}, fmt.Errorf("base32 timestamp overflows 128 bits")
+		}
+		// Shift the 128 bit value left by 5
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+	return Timestamp{Epoch: hi, Index: lo}, nil
+}
+
+// Decimal returns the timestamp as a decimal uint128 string, epoch*2^64 + index
+func (t Timestamp) Decimal() string {
+	return t.bigInt().String()
+}
+
+func ParseDecimal(s string) (Timestamp, error) {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok || n.Sign() < 0 || n.BitLen() > 128 {
+		return Timestamp{}, fmt.Errorf("invalid decimal uint128 timestamp %q", s)
+	}
+	b := make([]byte, Size)
+	return FromBytes(n.FillBytes(b))
+}
+
+func (t Timestamp) bigInt() *big.Int {
+	return new(big.Int).SetBytes(t.Bytes())
+}
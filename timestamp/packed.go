@@ -0,0 +1,126 @@
+package timestamp
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// PackedSize is the length of an encoded packed timestamp
+	PackedSize = 8
+	// PackedBase32Size is the length of a base32 encoded packed timestamp, 64 bits padded to 65
+	PackedBase32Size = 13
+
+	// minPhysicalBits is how many bits the unix milliseconds need until the year 2109
+	minPhysicalBits = 42
+	// minLogicalBits keeps decimal encodings at least 17 digits, so they are never mistaken for hex or base32
+	minLogicalBits = 13
+)
+
+// Packing packs hybrid timestamps into a single uint64, like a TiDB TSO: the unix millisecond of the epoch
+// in the high bits, followed by LogicalBits of index. It is only unique and ordered when every epoch is a
+// whole millisecond, and indexes are at most MaxLogical.
+type Packing struct {
+	LogicalBits int
+}
+
+func (p Packing) Validate() error {
+	if p.LogicalBits < minLogicalBits || p.LogicalBits > 64-minPhysicalBits {
+		return fmt.Errorf("logical bits must be between %d and %d, got %d", minLogicalBits, 64-minPhysicalBits, p.LogicalBits)
+	}
+	return nil
+}
+
+// MaxLogical is the largest index that fits in the logical bits
+func (p Packing) MaxLogical() uint64 {
+	return 1<<p.LogicalBits - 1
+}
+
+// Pack packs a timestamp, dropping the sub-millisecond part of the epoch
+func (p Packing) Pack(t Timestamp) uint64 {
+	return (t.Epoch/1e6)<<p.LogicalBits | t.Index&p.MaxLogical()
+}
+
+// Unpack is the inverse of Pack
+func (p Packing) Unpack(v uint64) Timestamp {
+	return Timestamp{Epoch: (v >> p.LogicalBits) * 1e6, Index: v & p.MaxLogical()}
+}
+
+// AppendBytes appends the 8 byte encoding of the packed timestamp to dst
+func (p Packing) AppendBytes(dst []byte, t Timestamp) []byte {
+	return binary.BigEndian.AppendUint64(dst, p.Pack(t))
+}
+
+// ExpandedBytes returns every timestamp in the range packed, concatenated in order
+func (p Packing) ExpandedBytes(r Range) []byte {
+	b := make([]byte, 0, PackedSize*r.Count)
+	for it := r.Iter(); ; {
+		ts, ok := it.Next()
+		if !ok {
+			return b
+		}
+		b = p.AppendBytes(b, ts)
+	}
+}
+
+func (p Packing) FromBytes(b []byte) (Timestamp, error) {
+	if len(b) != PackedSize {
+		return Timestamp{}, fmt.Errorf("packed timestamp must be %d bytes, got %d", PackedSize, len(b))
+	}
+	return p.Unpack(binary.BigEndian.Uint64(b)), nil
+}
+
+// Hex returns the 16 character lowercase hex encoding of the packed timestamp
+func (p Packing) Hex(t Timestamp) string {
+	return hex.EncodeToString(p.AppendBytes(make([]byte, 0, PackedSize), t))
+}
+
+// Base32 returns the fixed width Crockford base32 encoding of the packed timestamp
+func (p Packing) Base32(t Timestamp) string {
+	var out [PackedBase32Size]byte
+	v := p.Pack(t)
+	for i := PackedBase32Size - 1; i >= 0; i-- {
+		out[i] = crockford[v&0x1f]
+		v >>= 5
+	}
+	return string(out[:])
+}
+
+func (p Packing) Decimal(t Timestamp) string {
+	return strconv.FormatUint(p.Pack(t), 10)
+}
+
+// Parse decodes a packed timestamp from any of its text encodings, which are told apart by length like Parse:
+// 16 characters is hex, 13 is base32, and anything else that fits a uint64 is decimal. Every other string is
+// parsed as a 16 byte timestamp instead.
+func (p Packing) Parse(s string) (Timestamp, error) {
+	switch len(s) {
+	case PackedSize * 2:
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return Timestamp{}, fmt.Errorf("error in hex.DecodeString: %w", err)
+		}
+		return p.FromBytes(b)
+	case PackedBase32Size:
+		var v uint64
+		for i, c := range strings.ToUpper(s) {
+			d := strings.IndexRune(crockford, c)
+			if d < 0 {
+				return Timestamp{}, fmt.Errorf("invalid base32 character %q", c)
+			}
+			if i == 0 && d > 1 {
+				// Only the low bit of the first character fits in 64 bits
+				return Timestamp{}, fmt.Errorf("base32 packed timestamp overflows 64 bits")
+			}
+			v = v<<5 | uint64(d)
+		}
+		return p.Unpack(v), nil
+	}
+	if v, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return p.Unpack(v), nil
+	}
+	return Parse(s)
+}
@@ -0,0 +1,134 @@
+package timestamp
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	// Size is the length of an encoded hybrid timestamp, an 8 byte epoch followed by an 8 byte epoch index
+	Size = 16
+	// RangeSize is the length of an encoded range, an 8 byte epoch, 8 byte start index, and 8 byte count
+	RangeSize = 24
+)
+
+type (
+	// Timestamp is a hybrid timestamp, ordered by epoch and then by index within the epoch
+	Timestamp struct {
+		// Epoch and Index are encoded as strings in JSON, because JavaScript can't represent a uint64
+		Epoch uint64 `json:"epoch,string"`
+		Index uint64 `json:"index,string"`
+	}
+
+	// Range is a reservation of Count sequential timestamps that share the same epoch,
+	// starting at StartIndex
+	Range struct {
+		Epoch      uint64 `json:"epoch,string"`
+		StartIndex uint64 `json:"startIndex,string"`
+		Count      uint64 `json:"count"`
+	}
+)
+
+// FromBytes decodes a 16 byte hybrid timestamp
+func FromBytes(b []byte) (Timestamp, error) {
+	if len(b) != Size {
+		return Timestamp{}, fmt.Errorf("timestamp must be %d bytes, got %d", Size, len(b))
+	}
+	return Timestamp{
+		Epoch: binary.BigEndian.Uint64(b[:8]),
+		Index: binary.BigEndian.Uint64(b[8:]),
+	}, nil
+}
+
+// AppendBytes appends the 16 byte encoding of the timestamp to dst
+func (t Timestamp) AppendBytes(dst []byte) []byte {
+	dst = binary.BigEndian.AppendUint64(dst, t.Epoch)
+	return binary.BigEndian.AppendUint64(dst, t.Index)
+}
+
+func (t Timestamp) Bytes() []byte {
+	return t.AppendBytes(make([]byte, 0, Size))
+}
+
+// Compare returns -1 if t is before o, 0 if they are equal, and 1 if t is after o
+func (t Timestamp) Compare(o Timestamp) int {
+	switch {
+	case t.Epoch < o.Epoch:
+		return -1
+	case t.Epoch > o.Epoch:
+		return 1
+	case t.Index < o.Index:
+		return -1
+	case t.Index > o.Index:
+		return 1
+	}
+	return 0
+}
+
+// RangeFromBytes decodes a 24 byte range
+func RangeFromBytes(b []byte) (Range, error) {
+	if len(b) != RangeSize {
+		return Range{}, fmt.Errorf("range must be %d bytes, got %d", RangeSize, len(b))
+	}
+	return Range{
+		Epoch:      binary.BigEndian.Uint64(b[:8]),
+		StartIndex: binary.BigEndian.Uint64(b[8:16]),
+		Count:      binary.BigEndian.Uint64(b[16:]),
+	}, nil
+}
+
+// Bytes returns the compact 24 byte encoding of the range
+func (r Range) Bytes() []byte {
+	b := make([]byte, 0, RangeSize)
+	b = binary.BigEndian.AppendUint64(b, r.Epoch)
+	b = binary.BigEndian.AppendUint64(b, r.StartIndex)
+	return binary.BigEndian.AppendUint64(b, r.Count)
+}
+
+// At returns the i-th timestamp of the range, it does not check bounds
+func (r Range) At(i uint64) Timestamp {
+	return Timestamp{Epoch: r.Epoch, Index: r.StartIndex + i}
+}
+
+func (r Range) First() Timestamp {
+	return r.At(0)
+}
+
+func (r Range) Last() Timestamp {
+	return r.At(r.Count - 1)
+}
+
+// ExpandedBytes returns every timestamp in the range as concatenated 16 byte timestamps
+func (r Range) ExpandedBytes() []byte {
+	b := make([]byte, 0, Size*r.Count)
+	for i := uint64(0); i < r.Count; i++ {
+		b = r.At(i).AppendBytes(b)
+	}
+	return b
+}
+
+// Iter returns an iterator over the timestamps in the range, so clients can consume
+// large reservations without materializing them
+func (r Range) Iter() *RangeIterator {
+	return &RangeIterator{r: r}
+}
+
+type RangeIterator struct {
+	r    Range
+	next uint64
+}
+
+// Next returns the next timestamp in the range, or false once the range is exhausted
+func (it *RangeIterator) Next() (Timestamp, bool) {
+	if it.next >= it.r.Count {
+		return Timestamp{}, false
+	}
+	t := it.r.At(it.next)
+	it.next++
+	return t, true
+}
+
+// Remaining returns how many timestamps have not been returned by Next yet
+func (it *RangeIterator) Remaining() uint64 {
+	return it.r.Count - it.next
+}
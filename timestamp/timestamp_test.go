@@ -0,0 +1,143 @@
+package timestamp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeRoundTrip(t *testing.T) {
+	r := Range{Epoch: 1720000000000000000, StartIndex: 42, Count: 3}
+
+	decoded, err := RangeFromBytes(r.Bytes())
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, r, decoded)
+
+	_, err = RangeFromBytes(r.Bytes()[:16])
+	assert.NotNil(t, err)
+}
+
+func TestRangeExpandedMatchesIter(t *testing.T) {
+	r := Range{Epoch: 1720000000000000000, StartIndex: 42, Count: 3}
+	expanded := r.ExpandedBytes()
+	assert.Equal(t, Size*3, len(expanded))
+
+	it := r.Iter()
+	var prev Timestamp
+	for i := 0; i < 3; i++ {
+		ts, ok := it.Next()
+		if !assert.True(t, ok) {
+			return
+		}
+		assert.Equal(t, ts.Bytes(), expanded[i*Size:(i+1)*Size])
+
+		decoded, err := FromBytes(expanded[i*Size : (i+1)*Size])
+		assert.Nil(t, err)
+		assert.Equal(t, ts, decoded)
+		if i > 0 {
+			assert.Equal(t, 1, ts.Compare(prev))
+		}
+		prev = ts
+	}
+
+	_, ok := it.Next()
+	assert.False(t, ok)
+	assert.Equal(t, uint64(0), it.Remaining())
+	assert.Equal(t, r.Last(), prev)
+}
+
+func TestEncodingsRoundTrip(t *testing.T) {
+	for _, ts := range []Timestamp{
+		{},
+		{Epoch: 1720000000000000000, Index: 42},
+		{Epoch: ^uint64(0), Index: ^uint64(0)},
+	} {
+		decoded, err := ParseHex(ts.Hex())
+		assert.Nil(t, err)
+		assert.Equal(t, ts, decoded)
+
+		assert.Equal(t, Base32Size, len(ts.Base32()))
+		decoded, err = ParseBase32(ts.Base32())
+		assert.Nil(t, err)
+		assert.Equal(t, ts, decoded)
+
+		decoded, err = ParseDecimal(ts.Decimal())
+		assert.Nil(t, err)
+		assert.Equal(t, ts, decoded)
+	}
+
+	assert.Equal(t, "18446744073709551616", Timestamp{Epoch: 1}.Decimal())
+	_, err := ParseBase32("80000000000000000000000000")
+	assert.NotNil(t, err)
+}
+
+func TestBase32Sortable(t *testing.T) {
+	ordered := []Timestamp{
+		{Epoch: 1, Index: ^uint64(0)},
+		{Epoch: 2, Index: 0},
+		{Epoch: 2, Index: 1},
+		{Epoch: 2, Index: 32},
+		{Epoch: 1 << 40, Index: 0},
+	}
+	for i := 1; i < len(ordered); i++ {
+		assert.Less(t, ordered[i-1].Base32(), ordered[i].Base32())
+	}
+}
+
+func TestParse(t *testing.T) {
+	ts := Timestamp{Epoch: 1720000000000000000, Index: 7}
+	for _, encoded := range []string{ts.Hex(), ts.Base32(), ts.Decimal()} {
+		decoded, err := Parse(encoded)
+		if !assert.Nil(t, err, encoded) {
+			return
+		}
+		assert.Equal(t, ts, decoded)
+	}
+
+	_, err := Parse("not a timestamp")
+	assert.NotNil(t, err)
+}
+
+func TestPackedRoundTrip(t *testing.T) {
+	p := Packing{LogicalBits: 18}
+	assert.Nil(t, p.Validate())
+	assert.NotNil(t, Packing{LogicalBits: 23}.Validate())
+	assert.NotNil(t, Packing{LogicalBits: 12}.Validate())
+
+	ts := Timestamp{Epoch: 1720000000123000000, Index: 42}
+	assert.Equal(t, uint64(1720000000123)<<18|42, p.Pack(ts))
+	assert.Equal(t, ts, p.Unpack(p.Pack(ts)))
+
+	for _, encoded := range []string{p.Hex(ts), p.Base32(ts), p.Decimal(ts), ts.Hex()} {
+		decoded, err := p.Parse(encoded)
+		if !assert.Nil(t, err, encoded) {
+			return
+		}
+		assert.Equal(t, ts, decoded)
+	}
+
+	r := Range{Epoch: ts.Epoch, StartIndex: 1, Count: 3}
+	expanded := p.ExpandedBytes(r)
+	assert.Equal(t, PackedSize*3, len(expanded))
+	decoded, err := p.FromBytes(expanded[PackedSize*2:])
+	assert.Nil(t, err)
+	assert.Equal(t, r.Last(), decoded)
+
+	_, err = p.Parse("ZZZZZZZZZZZZZ")
+	assert.NotNil(t, err)
+}
+
+func TestPackedSortable(t *testing.T) {
+	p := Packing{LogicalBits: 18}
+	ordered := []Timestamp{
+		{Epoch: 1720000000000000000, Index: 1},
+		{Epoch: 1720000000000000000, Index: 1<<18 - 1},
+		{Epoch: 1720000000001000000, Index: 1},
+	}
+	for i := 1; i < len(ordered); i++ {
+		assert.Less(t, p.Pack(ordered[i-1]), p.Pack(ordered[i]))
+		assert.Less(t, p.Base32(ordered[i-1]), p.Base32(ordered[i]))
+	}
+}
@@ -9,8 +9,52 @@ var (
 
 	NodeID = uint64(GetEnvOrDefaultInt("NODE_ID", 0))
 
-	TimestampRequestBuffer = uint64(GetEnvOrDefaultInt("TIMESTAMP_REQUEST_BUFFER", 10000))
-	EpochIntervalMS        = uint64(GetEnvOrDefaultInt("EPOCH_INTERVAL_MS", 100))
+	Shards    = GetEnvOrDefaultInt("SHARDS", 1)
+	ShardBits = GetEnvOrDefaultInt("SHARD_BITS", 8)
+
+	Namespaces = os.Getenv("NAMESPACES")
+
+	TimestampRequestBuffer  = uint64(GetEnvOrDefaultInt("TIMESTAMP_REQUEST_BUFFER", 10000))
+	TimestampOverflowBuffer = uint64(GetEnvOrDefaultInt("TIMESTAMP_OVERFLOW_BUFFER", 0))
+	QueueBudgetMS           = GetEnvOrDefaultInt("TIMESTAMP_QUEUE_BUDGET_MS", 0)
+	TimestampMaxCount       = GetEnvOrDefaultInt("TIMESTAMP_MAX_COUNT", 10_000)
+	TimestampMaxRangeCount  = GetEnvOrDefaultInt("TIMESTAMP_MAX_RANGE_COUNT", 10_000_000)
+	MinTimestampMaxLeadMS   = GetEnvOrDefaultInt("MIN_TIMESTAMP_MAX_LEAD_MS", 60_000)
+	CommitWaitMaxMS         = GetEnvOrDefaultInt("COMMIT_WAIT_MAX_MS", 10_000)
+	SequenceBlockSize       = GetEnvOrDefaultInt("SEQUENCE_BLOCK_SIZE", 1000)
+	LockMaxTTLMS            = GetEnvOrDefaultInt("LOCK_MAX_TTL_MS", 3_600_000)
+	SafepointMaxTTLMS       = GetEnvOrDefaultInt("SAFEPOINT_MAX_TTL_MS", 86_400_000)
+
+	SnowflakeEpochMS   = GetEnvOrDefaultInt("SNOWFLAKE_EPOCH_MS", 1_704_067_200_000)
+	SnowflakeTimeBits  = GetEnvOrDefaultInt("SNOWFLAKE_TIME_BITS", 41)
+	SnowflakeIndexBits = GetEnvOrDefaultInt("SNOWFLAKE_INDEX_BITS", 22)
+
+	NormalPriorityShedPercent = GetEnvOrDefaultInt("NORMAL_PRIORITY_SHED_PERCENT", 90)
+	LowPriorityShedPercent    = GetEnvOrDefaultInt("LOW_PRIORITY_SHED_PERCENT", 50)
+
+	EpochIntervalMS     = uint64(GetEnvOrDefaultInt("EPOCH_INTERVAL_MS", 100))
+	MaxClockErrorMicros = GetEnvOrDefaultInt("MAX_CLOCK_ERROR_US", 1000)
+
+	TimestampLayout   = os.Getenv("TIMESTAMP_LAYOUT")
+	PackedLogicalBits = GetEnvOrDefaultInt("PACKED_LOGICAL_BITS", 18)
+
+	PDAPI        = os.Getenv("PD_API") == "1"
+	PDClientURLs = os.Getenv("PD_CLIENT_URLS")
+
+	RESPPort           = os.Getenv("RESP_PORT")
+	RESPAdvertiseAddrs = os.Getenv("RESP_ADVERTISE_ADDRS")
+
+	TCPPort           = os.Getenv("TCP_PORT")
+	TCPAdvertiseAddrs = os.Getenv("TCP_ADVERTISE_ADDRS")
+
+	QUICPort           = os.Getenv("QUIC_PORT")
+	QUICAdvertiseAddrs = os.Getenv("QUIC_ADVERTISE_ADDRS")
+
+	IssueMode           = os.Getenv("ISSUE_MODE")
+	DriftIntervalMicros = GetEnvOrDefaultInt("DRIFT_INTERVAL_US", 10_000)
 
 	EpochIntervalDeadlineLimit = GetEnvOrDefaultInt("EPOCH_DEADLINE_LIMIT", 100)
+
+	BatchLingerMicros = GetEnvOrDefaultInt("BATCH_LINGER_US", 0)
+	BatchMaxSize      = GetEnvOrDefaultInt("BATCH_MAX_SIZE", 0)
 )
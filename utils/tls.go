@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	certFile = "cert.pem"
+	keyFile  = "key.pem"
+)
+
+var tlsCertMu sync.Mutex
+
+// GenerateTLSCert loads the self-signed certificate from cert.pem and key.pem, generating them if they
+// don't exist. Every QUIC listener shares it.
+func GenerateTLSCert() (tls.Certificate, error) {
+	// Listeners start concurrently, so only one generates the files
+	tlsCertMu.Lock()
+	defer tlsCertMu.Unlock()
+
+	// Check if certificate and key files exist
+	if fileExists(certFile) && fileExists(keyFile) {
+		// Load existing certificate and key
+		return tls.LoadX509KeyPair(certFile, keyFile)
+	}
+
+	// Generate a new certificate and key
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{
+			Organization: []string{"Example Co"},
+		},
+		NotBefore: time.Now(),
+		NotAfter:  time.Now().Add(time.Hour * 24 * 180), // Valid for 180 days
+		KeyUsage:  x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+		},
+		BasicConstraintsValid: true,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	// Save the certificate
+	certOut, err := os.Create(certFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	pem.Encode(certOut, &pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	certOut.Close()
+
+	// Save the key
+	keyOut, err := os.Create(keyFile)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	pem.Encode(keyOut, &pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes})
+	keyOut.Close()
+
+	// Load the newly created certificate and key
+	return tls.LoadX509KeyPair(certFile, keyFile)
+}
+
+func fileExists(filename string) bool {
+	_, err := os.Stat(filename)
+	return !os.IsNotExist(err)
+}
@@ -55,6 +55,26 @@ func GetEnvOrDefaultInt(env string, defaultVal int64) int64 {
 	}
 }
 
+// ParseNodeAddrs parses a JSON object of addresses by node ID, like PD_CLIENT_URLS, empty if s is empty
+func ParseNodeAddrs(s string) (map[uint64]string, error) {
+	addrs := map[uint64]string{}
+	if s == "" {
+		return addrs, nil
+	}
+	var byNodeID map[string]string
+	if err := json.Unmarshal([]byte(s), &byNodeID); err != nil {
+		return nil, err
+	}
+	for nodeID, addr := range byNodeID {
+		id, err := strconv.ParseUint(nodeID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid node ID %q: %w", nodeID, err)
+		}
+		addrs[id] = addr
+	}
+	return addrs, nil
+}
+
 func GenRandomID(prefix string) string {
 	return prefix + gonanoid.MustGenerate("abcdefghijklmonpqrstuvwxyzABCDEFGHIJKLMONPQRSTUVWXYZ0123456789", 22)
 }